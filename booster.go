@@ -0,0 +1,155 @@
+package mtg
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// boosterSlotRarities maps booster slot names which differ from the rarity
+// of the matching cards.
+var boosterSlotRarities = map[string]Rarity{
+	"land": RarityBasicLand,
+}
+
+// slotRarityWeights weights the rarities of slots offering several of them.
+// A mythic rare replaces the rare in about one of eight boosters. Rarities
+// missing here weigh 1.
+var slotRarityWeights = map[Rarity]int{
+	RarityRare:       7,
+	RarityMythicRare: 1,
+}
+
+// slotRarityWeight returns the weight of a rarity within a slot.
+func slotRarityWeight(rarity Rarity) int {
+	if w, ok := slotRarityWeights[rarity]; ok {
+		return w
+	}
+	return 1
+}
+
+// SimulateBoosters generates count booster packs locally from the booster
+// definition of the set. The cards of the set are fetched once and reused for
+// all packs. Packs are reproducible for the same seed and card pool.
+// For slots offering several rarities one of them is chosen according to
+// slotRarityWeights, so rare slots hold a mythic rare in about one of eight
+// packs. A pack never holds the same card twice unless a rarity runs out of
+// cards. Slots without matching cards, such as marketing inserts, are skipped.
+func (s *Set) SimulateBoosters(count int, seed int64) ([][]*Card, error) {
+	if count < 0 {
+		return nil, fmt.Errorf("Invalid booster count %d", count)
+	}
+	if len(s.Booster) == 0 {
+		return nil, fmt.Errorf("Set %q has no booster", string(s.SetCode))
+	}
+
+	pool, err := s.CardsByRarity()
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	packs := make([][]*Card, count)
+	for i := range packs {
+		packs[i] = s.buildBooster(pool, rnd)
+	}
+	return packs, nil
+}
+
+// slotRarity returns the rarity of cards filling a booster slot.
+func slotRarity(slot string) Rarity {
+	if mapped, ok := boosterSlotRarities[strings.ToLower(strings.TrimSpace(slot))]; ok {
+		return mapped
+	}
+	return ParseRarity(slot)
+}
+
+// BoosterRarityDistribution returns the expected number of cards of each
+// rarity in one booster, keyed like CardsByRarity, e.g. RarityRare.
+// Slots offering several rarities are split by the same weights
+// SimulateBoosters uses, as the actual pull rates are not part of the set
+// data. Non-card slots such as "marketing" are included under their own name.
+func (s *Set) BoosterRarityDistribution() map[Rarity]float64 {
+	dist := make(map[Rarity]float64)
+	for _, slot := range s.Booster {
+		total := 0
+		for _, rarity := range slot {
+			total += slotRarityWeight(slotRarity(rarity))
+		}
+		for _, name := range slot {
+			rarity := slotRarity(name)
+			dist[rarity] += float64(slotRarityWeight(rarity)) / float64(total)
+		}
+	}
+	return dist
+}
+
+// CardsByRarity fetches all cards of the set and groups them by rarity as
+// normalized by ParseRarity, e.g. RarityMythicRare, RaritySpecial or
+// RarityBasicLand.
+func (s *Set) CardsByRarity() (map[Rarity][]*Card, error) {
+	cards, err := NewQuery().WhereSet(s.SetCode).All()
+	if err != nil {
+		return nil, err
+	}
+	return cardsByRarity(cards), nil
+}
+
+// cardsByRarity groups cards by their normalized rarity.
+func cardsByRarity(cards []*Card) map[Rarity][]*Card {
+	pool := make(map[Rarity][]*Card)
+	for _, card := range cards {
+		rarity := ParseRarity(card.Rarity)
+		pool[rarity] = append(pool[rarity], card)
+	}
+	return pool
+}
+
+// buildBooster fills every slot of the booster with a random card.
+func (s *Set) buildBooster(pool map[Rarity][]*Card, rnd *rand.Rand) []*Card {
+	var pack []*Card
+	picked := make(map[*Card]bool)
+	for _, slot := range s.Booster {
+		var options []Rarity
+		total := 0
+		for _, name := range slot {
+			rarity := slotRarity(name)
+			if len(pool[rarity]) > 0 {
+				options = append(options, rarity)
+				total += slotRarityWeight(rarity)
+			}
+		}
+		if len(options) == 0 {
+			continue
+		}
+
+		n := rnd.Intn(total)
+		rarity := options[0]
+		for _, option := range options {
+			if n -= slotRarityWeight(option); n < 0 {
+				rarity = option
+				break
+			}
+		}
+
+		card := pickUnused(pool[rarity], picked, rnd)
+		picked[card] = true
+		pack = append(pack, card)
+	}
+	return pack
+}
+
+// pickUnused returns a random card which is not yet picked. If all cards are
+// picked it returns any of them.
+func pickUnused(cards []*Card, picked map[*Card]bool, rnd *rand.Rand) *Card {
+	var unused []*Card
+	for _, card := range cards {
+		if !picked[card] {
+			unused = append(unused, card)
+		}
+	}
+	if len(unused) == 0 {
+		unused = cards
+	}
+	return unused[rnd.Intn(len(unused))]
+}
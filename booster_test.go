@@ -0,0 +1,131 @@
+package mtg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSimulateBoostersNegativeCount(t *testing.T) {
+	s := &Set{SetCode: "LEA", Booster: []BoosterContent{{"rare"}}}
+	if _, err := s.SimulateBoosters(-1, 1); err == nil {
+		t.Error("expected an error for a negative count")
+	}
+}
+
+// testPool returns a card pool with n cards of each given rarity.
+func testPool(n int, rarities ...string) map[Rarity][]*Card {
+	var cards []*Card
+	for _, rarity := range rarities {
+		for i := 0; i < n; i++ {
+			cards = append(cards, &Card{Name: fmt.Sprintf("%s %d", rarity, i), Rarity: rarity})
+		}
+	}
+	return cardsByRarity(cards)
+}
+
+func TestBuildBoosterMythicRatio(t *testing.T) {
+	s := &Set{Booster: []BoosterContent{{"rare", "mythic rare"}}}
+	pool := testPool(10, "Rare", "Mythic Rare")
+	rnd := rand.New(rand.NewSource(1))
+
+	const packs = 8000
+	mythics := 0
+	for i := 0; i < packs; i++ {
+		if s.buildBooster(pool, rnd)[0].Rarity == "Mythic Rare" {
+			mythics++
+		}
+	}
+	if ratio := float64(mythics) / packs; math.Abs(ratio-1.0/8) > 0.02 {
+		t.Errorf("mythic ratio %.3f, want about 0.125", ratio)
+	}
+}
+
+func TestBuildBoosterNoDuplicates(t *testing.T) {
+	s := &Set{Booster: []BoosterContent{
+		{"common"}, {"common"}, {"common"}, {"common"}, {"common"},
+		{"uncommon"}, {"uncommon"}, {"uncommon"},
+		{"rare", "mythic rare"},
+		{"land"},
+	}}
+	pool := testPool(6, "Common", "Uncommon", "Rare", "Mythic Rare", "Basic Land")
+	rnd := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 500; i++ {
+		pack := s.buildBooster(pool, rnd)
+		if len(pack) != len(s.Booster) {
+			t.Fatalf("pack has %d cards, want %d", len(pack), len(s.Booster))
+		}
+		seen := make(map[*Card]bool)
+		for _, card := range pack {
+			if seen[card] {
+				t.Fatalf("pack %d holds %q twice", i, card.Name)
+			}
+			seen[card] = true
+		}
+	}
+}
+
+func TestBuildBoosterExhaustedRarity(t *testing.T) {
+	s := &Set{Booster: []BoosterContent{{"rare"}, {"rare"}, {"marketing"}}}
+	pool := testPool(1, "Rare")
+
+	pack := s.buildBooster(pool, rand.New(rand.NewSource(3)))
+	if len(pack) != 2 {
+		t.Fatalf("pack has %d cards, want 2 without the marketing slot", len(pack))
+	}
+	if pack[0] != pack[1] {
+		t.Error("the only rare should fill both rare slots")
+	}
+}
+
+func TestBoosterRarityDistribution(t *testing.T) {
+	s := &Set{Booster: []BoosterContent{
+		{"common"}, {"common"},
+		{"rare", "mythic rare"},
+		{"land"},
+	}}
+	want := map[Rarity]float64{
+		RarityCommon:     2,
+		RarityRare:       7.0 / 8,
+		RarityMythicRare: 1.0 / 8,
+		RarityBasicLand:  1,
+	}
+
+	got := s.BoosterRarityDistribution()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for rarity, n := range want {
+		if math.Abs(got[rarity]-n) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", rarity, got[rarity], n)
+		}
+	}
+}
+
+func TestParseRarity(t *testing.T) {
+	tests := map[string]Rarity{
+		"Common":       RarityCommon,
+		"mythic rare":  RarityMythicRare,
+		" Basic Land ": RarityBasicLand,
+		"SPECIAL":      RaritySpecial,
+		"Bonus":        Rarity("Bonus"),
+	}
+	for in, want := range tests {
+		if got := ParseRarity(in); got != want {
+			t.Errorf("ParseRarity(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCardsByRarityKeys(t *testing.T) {
+	pool := cardsByRarity([]*Card{
+		{Name: "a", Rarity: "mythic rare"},
+		{Name: "b", Rarity: "Mythic Rare"},
+		{Name: "c", Rarity: "Basic Land"},
+	})
+	if len(pool[RarityMythicRare]) != 2 || len(pool[RarityBasicLand]) != 1 || len(pool) != 2 {
+		t.Errorf("got pool %v", pool)
+	}
+}
@@ -1,10 +1,18 @@
 package mtg
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Ruling contains additional rule information about the card.
@@ -33,6 +41,79 @@ type Legality struct {
 	Legality string `json:"legality"`
 }
 
+// LegalityStatus is the typed form of Legality.Legality.
+type LegalityStatus string
+
+// Known legality statuses. LegalityUnknown is used for any other value and
+// for formats a card has no legality entry for.
+const (
+	LegalityUnknown    = LegalityStatus("")
+	LegalityLegal      = LegalityStatus("Legal")
+	LegalityBanned     = LegalityStatus("Banned")
+	LegalityRestricted = LegalityStatus("Restricted")
+)
+
+// Status returns the typed legality, ignoring case.
+func (l Legality) Status() LegalityStatus {
+	for _, status := range []LegalityStatus{LegalityLegal, LegalityBanned, LegalityRestricted} {
+		if strings.EqualFold(strings.TrimSpace(l.Legality), string(status)) {
+			return status
+		}
+	}
+	return LegalityUnknown
+}
+
+// Layout is the layout of a card as used by Card.Layout.
+type Layout string
+
+// Layouts recognized by the API.
+const (
+	LayoutNormal      = Layout("normal")
+	LayoutSplit       = Layout("split")
+	LayoutFlip        = Layout("flip")
+	LayoutDoubleFaced = Layout("double-faced")
+	LayoutToken       = Layout("token")
+	LayoutPlane       = Layout("plane")
+	LayoutScheme      = Layout("scheme")
+	LayoutPhenomenon  = Layout("phenomenon")
+	LayoutLeveler     = Layout("leveler")
+	LayoutVanguard    = Layout("vanguard")
+)
+
+// Rarity is the typed form of Card.Rarity.
+type Rarity string
+
+// Rarities used by the API.
+const (
+	RarityCommon     = Rarity("Common")
+	RarityUncommon   = Rarity("Uncommon")
+	RarityRare       = Rarity("Rare")
+	RarityMythicRare = Rarity("Mythic Rare")
+	// RaritySpecial is used for cards outside the usual rarities, such as
+	// timeshifted cards and some promos.
+	RaritySpecial = Rarity("Special")
+	// RarityBasicLand is used for basic lands.
+	RarityBasicLand = Rarity("Basic Land")
+)
+
+// knownRarities lists the Rarity constants for ParseRarity.
+var knownRarities = []Rarity{
+	RarityCommon, RarityUncommon, RarityRare, RarityMythicRare, RaritySpecial, RarityBasicLand,
+}
+
+// ParseRarity returns the Rarity of a rarity string such as Card.Rarity,
+// ignoring case and surrounding space, e.g. RarityMythicRare for
+// "mythic rare". Unknown rarities are returned trimmed but otherwise as is.
+func ParseRarity(rarity string) Rarity {
+	rarity = strings.TrimSpace(rarity)
+	for _, known := range knownRarities {
+		if strings.EqualFold(rarity, string(known)) {
+			return known
+		}
+	}
+	return Rarity(rarity)
+}
+
 // Card stores information about one single card.
 type Card struct {
 	// Name defines the name of the front of a card.
@@ -119,12 +200,10 @@ type Card struct {
 	// Timeshifted defines if the card was timeshifted in set.
 	Timeshifted bool `json:"timeshifted"`
 	// Hand defines the max hand size modifier.
-	// NOTE: Only exists for Vanguard cards.
-	// Hand defines the max hand size modifier.
-	// NOTE: Only exists for Vanguard cards.
+	// NOTE: Only exists for Vanguard cards, see VanguardStats.
 	Hand int `json:"hand"`
 	// Life defines starting life total modifier.
-	// NOTE: Only exists for Vanguard cards.
+	// NOTE: Only exists for Vanguard cards, see VanguardStats.
 	Life int `json:"life"`
 	// Reserved defines if card is reserved by Wizards Reprint Policy.
 	Reserved bool `json:"reserved"`
@@ -157,6 +236,123 @@ type Card struct {
 	// Legalities defines formats this card is legal, restricted or banned in.
 	// Objects defined as "format" and "legality" keys.
 	Legalities []Legality `json:"legalities"`
+	// RawExtra holds the JSON fields of the card which are not modelled by
+	// this struct, such as fields newly added to the API.
+	// NOTE: Only populated by DecodeCardsWithExtra.
+	RawExtra map[string]json.RawMessage `json:"-"`
+}
+
+// cardJSONKeys contains the lower case JSON keys of the fields of Card.
+var cardJSONKeys = func() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(Card{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			keys[strings.ToLower(name)] = true
+		}
+	}
+	return keys
+}()
+
+// DecodeCardsWithExtra decodes a cards response of the API, holding either a
+// "cards" array or a single "card" object. Unlike the cards returned by a
+// Query, the decoded cards keep the JSON fields Card does not model in
+// RawExtra. Use it on responses fetched from Query.URL when such fields are
+// needed; the other decoders skip them to avoid the extra work.
+func DecodeCardsWithExtra(r io.Reader) ([]*Card, error) {
+	var resp struct {
+		Card  json.RawMessage   `json:"card"`
+		Cards []json.RawMessage `json:"cards"`
+	}
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	raws := resp.Cards
+	if len(resp.Card) > 0 && string(resp.Card) != "null" {
+		raws = []json.RawMessage{resp.Card}
+	}
+	cards := make([]*Card, len(raws))
+	for i, raw := range raws {
+		card, err := unmarshalCardWithExtra(raw)
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = card
+	}
+	return cards, nil
+}
+
+// unmarshalCardWithExtra decodes one card and fills its RawExtra.
+func unmarshalCardWithExtra(data []byte) (*Card, error) {
+	card := new(Card)
+	if err := json.Unmarshal(data, card); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key := range fields {
+		if cardJSONKeys[strings.ToLower(key)] {
+			delete(fields, key)
+		}
+	}
+	if len(fields) > 0 {
+		card.RawExtra = fields
+	}
+	return card, nil
+}
+
+// String returns the string representation for the Card,
+// e.g. "Lightning Bolt {R} (LEA) — Instant".
+func (c *Card) String() string {
+	s := c.Name
+	if c.ManaCost != "" {
+		s += " " + c.ManaCost
+	}
+	if c.Set != "" {
+		s += fmt.Sprintf(" (%s)", c.Set)
+	}
+	if c.Type != "" {
+		s += " — " + c.Type
+	}
+	return s
+}
+
+// LegalityIn returns the legality of the card in the given format, e.g.
+// "Modern". It returns LegalityUnknown if the format is not listed.
+func (c *Card) LegalityIn(format string) LegalityStatus {
+	for _, l := range c.Legalities {
+		if strings.EqualFold(l.Format, format) {
+			return l.Status()
+		}
+	}
+	return LegalityUnknown
+}
+
+// IsLegalIn reports whether the card is legal in the given format.
+// Restricted cards are not considered legal.
+func (c *Card) IsLegalIn(format string) bool {
+	return c.LegalityIn(format) == LegalityLegal
+}
+
+// CardLegalities returns the legality of each card in each of the given
+// formats, keyed by card ID and then by format. Every requested format is
+// present for every card; formats a card has no legality entry for map to
+// the empty string (LegalityUnknown).
+func CardLegalities(cards []*Card, formats ...string) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(cards))
+	for _, card := range cards {
+		legalities := make(map[string]string, len(formats))
+		for _, format := range formats {
+			legalities[format] = string(card.LegalityIn(format))
+		}
+		result[card.ID] = legalities
+	}
+	return result
 }
 
 // ServerError is an error implementation for server messages.
@@ -172,6 +368,17 @@ func (s ServerError) Error() string {
 	return s.Message
 }
 
+// ValidationError lists all problems found by Card.Validate.
+type ValidationError struct {
+	// Problems describes each violated invariant.
+	Problems []string
+}
+
+// Error implements the error interface
+func (v *ValidationError) Error() string {
+	return "Invalid card: " + strings.Join(v.Problems, "; ")
+}
+
 // cardResponse defines response from cards API Get request.
 type cardResponse struct {
 	Card  *Card   `json:"card"`
@@ -193,7 +400,7 @@ func checkError(r *http.Response) error {
 
 // Fetch collects card by ID or MultiverseID; retuns Card pointer.
 func Fetch(filterID string) (*Card, error) {
-	resp, err := http.Get(fmt.Sprintf("%scards/%s", queryURL, filterID))
+	resp, err := httpGet(fmt.Sprintf("%scards/%s", queryURL, filterID))
 	if err != nil {
 		return nil, err
 	}
@@ -216,3 +423,458 @@ func Fetch(filterID string) (*Card, error) {
 
 	return cards[0], nil
 }
+
+// FetchBySetNumber collects the card with the given collector number in a
+// set, e.g. "180a". Letter suffixes are significant: "180" and "180a" are
+// different printings. An error is returned unless exactly one card matches.
+func FetchBySetNumber(code SetCode, number string) (*Card, error) {
+	cards, err := NewQuery().WhereSet(code).Where(CardNumber, number).All()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Card
+	for _, card := range cards {
+		if strings.EqualFold(card.Number, number) {
+			matches = append(matches, card)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("Card %s in set %s not found", number, code)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("Card %s in set %s is ambiguous, %d cards match", number, code, len(matches))
+	}
+}
+
+// FetchLatestPrinting returns the printing of the card from the most
+// recently released set in Printings. The set list is fetched once to compare
+// their release dates; sets without a valid release date are ignored. Cards printed
+// only once, whose latest printing is this one or whose sets have no dates
+// are returned as is. If the latest set holds several printings of the card,
+// the first one returned by the API is used.
+func (c *Card) FetchLatestPrinting() (*Card, error) {
+	if len(c.Printings) <= 1 {
+		return c, nil
+	}
+
+	sets, err := NewSetQuery().All()
+	if err != nil {
+		return nil, err
+	}
+	releaseDates := make(map[SetCode]time.Time, len(sets))
+	for _, set := range sets {
+		if date, err := parseDate(set.ReleaseDate); err == nil {
+			releaseDates[set.SetCode.Normalize()] = date
+		}
+	}
+
+	var latest SetCode
+	var latestDate time.Time
+	for _, code := range c.Printings {
+		code = code.Normalize()
+		date, ok := releaseDates[code]
+		if ok && (latest == "" || date.After(latestDate)) {
+			latest, latestDate = code, date
+		}
+	}
+	if latest == "" || latest == c.Set.Normalize() {
+		return c, nil
+	}
+
+	cards, err := NewQuery().WhereName(c.Name).WhereSet(latest).All()
+	if err != nil {
+		return nil, err
+	}
+	matches := FilterCards(cards, ByName(c.Name))
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("Card %q not found in set %s", c.Name, latest)
+	}
+	return matches[0], nil
+}
+
+// CardsByArtist returns all cards illustrated by the given artist.
+// Unlike WhereArtist, only exact matches are returned, ignoring case. Cards
+// credited to several artists joined by "&" match any of them.
+func CardsByArtist(name string) ([]*Card, error) {
+	cards, err := NewQuery().WhereArtist(name).All()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Card
+	for _, card := range cards {
+		for _, artist := range strings.Split(card.Artist, "&") {
+			if strings.EqualFold(strings.TrimSpace(artist), strings.TrimSpace(name)) {
+				result = append(result, card)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// CardsReleasedBetween returns the cards whose ReleaseDate lies between start
+// and end, both inclusive. Partial dates count as the start of their month
+// or year. The API only sets ReleaseDate for promo cards and cannot filter by
+// it, so all cards are streamed and filtered locally, which takes many
+// requests.
+func CardsReleasedBetween(start, end time.Time) ([]*Card, error) {
+	var cards []*Card
+	err := NewQuery().Stream(func(card *Card) error {
+		if t, ok := card.ReleaseTime(); ok && !t.Before(start) && !t.After(end) {
+			cards = append(cards, card)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// LoadCardsGzip decodes cards from gzipped JSON in the format of the API's
+// cards response, e.g. a compressed dump of {"cards": [...]}.
+// A truncated or corrupt file results in an error rather than partial data.
+func LoadCardsGzip(r io.Reader) ([]*Card, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("Opening gzipped cards: %w", err)
+	}
+	defer zr.Close()
+
+	cards, err := decodeCards(zr)
+	if err != nil {
+		return nil, fmt.Errorf("Reading gzipped cards: %w", err)
+	}
+	return cards, nil
+}
+
+// SiblingNames returns the names of the other parts of a split, flip or
+// double-faced card. The name of the card itself is excluded regardless of
+// whether the API listed it in Names, compared case-insensitively.
+func (c *Card) SiblingNames() []string {
+	var siblings []string
+	for _, name := range c.Names {
+		if !strings.EqualFold(name, c.Name) {
+			siblings = append(siblings, name)
+		}
+	}
+	return siblings
+}
+
+// collectorNumberDigits returns the length of the leading digits of Number.
+func (c *Card) collectorNumberDigits() int {
+	n := 0
+	for n < len(c.Number) && c.Number[n] >= '0' && c.Number[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// CollectorNumberInt returns the numeric part of the card's Number, ignoring
+// any suffix such as the "a" of "180a". The bool is false if Number does not
+// start with a digit, e.g. for "★".
+func (c *Card) CollectorNumberInt() (int, bool) {
+	n, err := strconv.Atoi(c.Number[:c.collectorNumberDigits()])
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// CollectorNumberSuffix returns the part of the card's Number following its
+// leading digits, e.g. "a" for "180a". It is empty for plain numbers.
+func (c *Card) CollectorNumberSuffix() string {
+	return c.Number[c.collectorNumberDigits():]
+}
+
+// wubrg is the canonical order of the color codes.
+const wubrg = "WUBRG"
+
+// ColorIdentityString returns the color identity as a canonical string of
+// color codes in WUBRG order, e.g. "WU" for both ["U", "W"] and ["W", "U"].
+// Colorless cards return an empty string.
+func (c *Card) ColorIdentityString() string {
+	codes := make([]string, 0, len(c.ColorIdentity))
+	for _, code := range c.ColorIdentity {
+		codes = append(codes, strings.ToUpper(code))
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		pi, pj := strings.Index(wubrg, codes[i]), strings.Index(wubrg, codes[j])
+		if pi == -1 || pj == -1 {
+			// Unknown codes go last, in alphabetical order.
+			return pj == -1 && (pi != -1 || codes[i] < codes[j])
+		}
+		return pi < pj
+	})
+	return strings.Join(codes, "")
+}
+
+// flavorMarkup removes italic markers some feeds add to flavor text.
+var flavorMarkup = strings.NewReplacer("<i>", "", "</i>", "")
+
+// FlavorLines returns the lines of the flavor text with surrounding
+// whitespace and italic markers removed. Empty lines are skipped.
+func (c *Card) FlavorLines() []string {
+	var lines []string
+	for _, line := range strings.Split(flavorMarkup.Replace(c.Flavor), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// FlavorAttribution returns the author a quote in the flavor text is
+// attributed to, i.e. the text after the long dash of a last line such as
+// "—Urza". The bool is false if the flavor text has no attribution.
+func (c *Card) FlavorAttribution() (string, bool) {
+	lines := c.FlavorLines()
+	if len(lines) == 0 {
+		return "", false
+	}
+
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(last, "—") {
+		return "", false
+	}
+	author := strings.TrimSpace(strings.TrimPrefix(last, "—"))
+	return author, author != ""
+}
+
+// HasRulings reports whether the card has any rulings.
+func (c *Card) HasRulings() bool {
+	return len(c.Rulings) > 0
+}
+
+// RulingsAfter returns the rulings released after the given date.
+// Rulings with a malformed date are left out.
+func (c *Card) RulingsAfter(date time.Time) []*Ruling {
+	var rulings []*Ruling
+	for _, ruling := range c.Rulings {
+		if d, err := parseDate(ruling.Date); err == nil && d.After(date) {
+			rulings = append(rulings, ruling)
+		}
+	}
+	return rulings
+}
+
+// TypeLine reconstructs the printed type line from Supertypes, Types and
+// Subtypes, e.g. "Legendary Creature — Elf Warrior". The long dash is only
+// added if the card has subtypes. If the card has no Types, Type is returned.
+func (c *Card) TypeLine() string {
+	if len(c.Types) == 0 {
+		return c.Type
+	}
+
+	line := strings.Join(append(append([]string{}, c.Supertypes...), c.Types...), " ")
+	if len(c.Subtypes) > 0 {
+		line += " — " + strings.Join(c.Subtypes, " ")
+	}
+	return line
+}
+
+// IsToken reports whether the card is a token rather than a playable card.
+func (c *Card) IsToken() bool {
+	return Layout(strings.ToLower(c.Layout)) == LayoutToken
+}
+
+// IsVanguard reports whether the card is a Vanguard card, the only kind of
+// card with hand and life modifiers.
+func (c *Card) IsVanguard() bool {
+	return Layout(strings.ToLower(c.Layout)) == LayoutVanguard ||
+		containsFold(c.Types, "Vanguard")
+}
+
+// VanguardStats returns the hand size and starting life modifiers of a
+// Vanguard card. The bool is false for all other cards, whose Hand and Life
+// are 0 because the fields are absent rather than because they modify by 0.
+func (c *Card) VanguardStats() (hand, life int, ok bool) {
+	if !c.IsVanguard() {
+		return 0, 0, false
+	}
+	return c.Hand, c.Life, true
+}
+
+// ReleaseTime parses the ReleaseDate of the card. Partial dates (YYYY-MM or
+// YYYY) are treated as the start of that month or year. The bool is false if
+// the ReleaseDate is missing or malformed.
+func (c *Card) ReleaseTime() (time.Time, bool) {
+	t, err := parseDate(c.ReleaseDate)
+	return t, err == nil
+}
+
+// CompareReleaseDates orders two cards by their release date.
+// It returns -1 if a was released before b, 1 if it was released after b and
+// 0 otherwise. Partial dates count as the start of their month or year, so
+// "2019" comes before "2019-05-01". Cards without a valid release date are
+// ordered after all others; ties are broken by the card name.
+func CompareReleaseDates(a, b *Card) int {
+	ta, okA := a.ReleaseTime()
+	tb, okB := b.ReleaseTime()
+	switch {
+	case !okA && okB:
+		return 1
+	case okA && !okB:
+		return -1
+	case okA && okB && !ta.Equal(tb):
+		if ta.Before(tb) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// Validate checks the fields every card must have: a name, a well-formed set
+// code and a non-negative CMC. Optional fields are not checked. All problems
+// are reported together in a *ValidationError.
+func (c *Card) Validate() error {
+	var problems []string
+	if strings.TrimSpace(c.Name) == "" {
+		problems = append(problems, "name is empty")
+	}
+	if !setCodeRE.MatchString(string(c.Set.Normalize())) {
+		problems = append(problems, fmt.Sprintf("set code %q is invalid", string(c.Set)))
+	}
+	if c.CMC < 0 || math.IsNaN(c.CMC) {
+		problems = append(problems, fmt.Sprintf("cmc %v is invalid", c.CMC))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// diacritics maps lower case letters with diacritics to plain ASCII.
+var diacritics = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ā", "a",
+	"æ", "ae", "ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e", "ē", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ī", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "ō", "o",
+	"œ", "oe", "ß", "ss", "ù", "u", "ú", "u", "û", "u", "ü", "u", "ū", "u",
+	"ý", "y", "ÿ", "y",
+)
+
+// NormalizedName returns the name of the card as a lookup key: lower case,
+// without diacritics and with surrounding and repeated whitespace removed,
+// e.g. "aether vial" for "Æther Vial" and "lim-dul" for "Lim-Dûl".
+func (c *Card) NormalizedName() string {
+	name := diacritics.Replace(strings.ToLower(c.Name))
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// IsUnSet reports whether the card is a silver-bordered joke card, such as
+// the cards of Unglued. Border is only set on cards whose border differs from
+// their set, so the set of the card is used as a fallback: cards of an "un"
+// set or with the set's silver border count as well. The set may be nil if
+// it is unknown, in which case only the card's own Border is checked.
+func (c *Card) IsUnSet(set *Set) bool {
+	if c.Border != "" {
+		return strings.EqualFold(c.Border, "silver")
+	}
+	if set == nil {
+		return false
+	}
+	return set.SetType() == SetTypeUn || strings.EqualFold(set.Border, "silver")
+}
+
+// SplitHalves returns the names of the halves of a split card, parsed from a
+// name like "Fire // Ice" or else taken from Names. The bool is false if the
+// card is not a split card; flip and double-faced cards are not split cards.
+func (c *Card) SplitHalves() ([]string, bool) {
+	if strings.Contains(c.Name, "//") {
+		halves := strings.Split(c.Name, "//")
+		for i, half := range halves {
+			halves[i] = strings.TrimSpace(half)
+		}
+		return halves, true
+	}
+
+	if Layout(strings.ToLower(c.Layout)) == LayoutSplit && len(c.Names) > 1 {
+		return append([]string(nil), c.Names...), true
+	}
+	return nil, false
+}
+
+// ForeignNamesByLanguage returns the foreign names of the card keyed by
+// language, removing duplicate entries of the same language. Of duplicates
+// the first entry with a MultiverseID wins, otherwise the first entry.
+func (c *Card) ForeignNamesByLanguage() map[string]ForeignCardName {
+	names := make(map[string]ForeignCardName, len(c.ForeignNames))
+	for _, fn := range c.ForeignNames {
+		prev, ok := names[fn.Language]
+		if !ok || (prev.MultiverseID == 0 && fn.MultiverseID != 0) {
+			names[fn.Language] = fn
+		}
+	}
+	return names
+}
+
+// CMCInt returns the converted mana cost as an integer for curve buckets.
+// Fractional costs of un-set cards are rounded down, so ½ counts as 0 and
+// 2½ as 2.
+func (c *Card) CMCInt() int {
+	return int(math.Floor(c.CMC))
+}
+
+// CMCBucket works like CMCInt but puts all costs of max and above into the
+// max bucket, e.g. CMCBucket(7) for a curve with a "7+" column.
+func (c *Card) CMCBucket(max int) int {
+	if n := c.CMCInt(); n < max {
+		return n
+	}
+	return max
+}
+
+// cloneStrings copies a string slice, keeping nil slices nil.
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+// Clone returns a deep copy of the card which shares no slices, maps or
+// rulings with the original, so either can be modified safely.
+func (c *Card) Clone() *Card {
+	clone := *c
+	clone.Names = cloneStrings(c.Names)
+	clone.Colors = cloneStrings(c.Colors)
+	clone.ColorIdentity = cloneStrings(c.ColorIdentity)
+	clone.Types = cloneStrings(c.Types)
+	clone.Supertypes = cloneStrings(c.Supertypes)
+	clone.Subtypes = cloneStrings(c.Subtypes)
+	clone.Variations = cloneStrings(c.Variations)
+
+	if c.Rulings != nil {
+		clone.Rulings = make([]*Ruling, len(c.Rulings))
+		for i, r := range c.Rulings {
+			if r != nil {
+				ruling := *r
+				clone.Rulings[i] = &ruling
+			}
+		}
+	}
+	if c.ForeignNames != nil {
+		clone.ForeignNames = append([]ForeignCardName{}, c.ForeignNames...)
+	}
+	if c.Printings != nil {
+		clone.Printings = append([]SetCode{}, c.Printings...)
+	}
+	if c.Legalities != nil {
+		clone.Legalities = append([]Legality{}, c.Legalities...)
+	}
+	if c.RawExtra != nil {
+		clone.RawExtra = make(map[string]json.RawMessage, len(c.RawExtra))
+		for k, v := range c.RawExtra {
+			clone.RawExtra[k] = append(json.RawMessage{}, v...)
+		}
+	}
+	return &clone
+}
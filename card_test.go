@@ -0,0 +1,48 @@
+package mtg
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCardsWithExtra(t *testing.T) {
+	const body = `{"cards":[{"name":"Forest","Set":"LEA","newField":{"a":1}},{"name":"Island"}]}`
+
+	cards, err := DecodeCardsWithExtra(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("got %d cards, want 2", len(cards))
+	}
+	if cards[0].Set != "LEA" {
+		t.Errorf("known field not decoded: %+v", cards[0])
+	}
+	if len(cards[0].RawExtra) != 1 || string(cards[0].RawExtra["newField"]) != `{"a":1}` {
+		t.Errorf("got RawExtra %v, want only newField", cards[0].RawExtra)
+	}
+	if cards[1].RawExtra != nil {
+		t.Errorf("got RawExtra %v without unknown fields, want nil", cards[1].RawExtra)
+	}
+}
+
+func TestDecodeCardsWithExtraSingleCard(t *testing.T) {
+	cards, err := DecodeCardsWithExtra(strings.NewReader(`{"card":{"name":"Forest","extra":true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 1 || string(cards[0].RawExtra["extra"]) != "true" {
+		t.Errorf("got %+v", cards)
+	}
+}
+
+func TestUnmarshalCardSkipsExtra(t *testing.T) {
+	var c Card
+	if err := json.Unmarshal([]byte(`{"name":"Forest","newField":1}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.RawExtra != nil {
+		t.Errorf("plain decoding filled RawExtra: %v", c.RawExtra)
+	}
+}
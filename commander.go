@@ -0,0 +1,86 @@
+package mtg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pairing is the ability which lets a commander share the command zone.
+type Pairing int
+
+const (
+	// PairingNone means the commander stands alone.
+	PairingNone Pairing = iota
+	// PairingPartner pairs with any other commander with partner.
+	PairingPartner
+	// PairingPartnerWith pairs with one specific, named card.
+	PairingPartnerWith
+	// PairingFriendsForever pairs with any other commander with friends forever.
+	PairingFriendsForever
+	// PairingChooseBackground pairs with any Background enchantment.
+	PairingChooseBackground
+	// PairingDoctorsCompanion pairs with any Time Lord Doctor.
+	PairingDoctorsCompanion
+)
+
+// Pairing returns the pairing ability of the card found in its oracle text.
+// For PairingPartnerWith the name of the partner is returned as well.
+func (c *Card) Pairing() (Pairing, string) {
+	for _, line := range strings.Split(c.Text, "\n") {
+		// Drop reminder text.
+		if i := strings.Index(line, "("); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "Partner with "):
+			return PairingPartnerWith, strings.TrimSpace(strings.TrimPrefix(line, "Partner with "))
+		case line == "Partner":
+			return PairingPartner, ""
+		case line == "Friends forever":
+			return PairingFriendsForever, ""
+		case line == "Choose a Background":
+			return PairingChooseBackground, ""
+		case line == "Doctor's companion":
+			return PairingDoctorsCompanion, ""
+		}
+	}
+	return PairingNone, ""
+}
+
+// FetchCommanderPair collects the commander with the given name and, if it
+// has "Partner with", the named partner. The second card is nil if the
+// commander has no fixed partner; use Card.Pairing to see whether it may
+// still be paired freely, e.g. with any Background.
+func FetchCommanderPair(name string) (*Card, *Card, error) {
+	commander, err := fetchCardByName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pairing, partnerName := commander.Pairing()
+	if pairing != PairingPartnerWith {
+		return commander, nil, nil
+	}
+
+	partner, err := fetchCardByName(partnerName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Partner of %s: %w", commander.Name, err)
+	}
+	return commander, partner, nil
+}
+
+// fetchCardByName collects a printing of the card with exactly the given name.
+func fetchCardByName(name string) (*Card, error) {
+	cards, err := NewQuery().WhereName(name).All()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := FilterCards(cards, ByName(name))
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("Card %q not found", name)
+	}
+	return matches[0], nil
+}
@@ -0,0 +1,39 @@
+package mtg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayouts lists the date precisions used by the API, most precise first.
+var dateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// parseDate parses a full or partial date (YYYY-MM-DD, YYYY-MM or YYYY).
+// Partial dates are treated as the start of the given month or year.
+func parseDate(date string) (time.Time, error) {
+	date = strings.TrimSpace(date)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid date %q", date)
+}
+
+// parseDatePeriod parses a full or partial date like parseDate and also
+// returns the end of the period it covers: the next day, month or year.
+func parseDatePeriod(date string) (start, end time.Time, err error) {
+	if start, err = parseDate(date); err != nil {
+		return start, end, err
+	}
+	switch len(strings.TrimSpace(date)) {
+	case len("2006"):
+		end = start.AddDate(1, 0, 0)
+	case len("2006-01"):
+		end = start.AddDate(0, 1, 0)
+	default:
+		end = start.AddDate(0, 0, 1)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,115 @@
+package mtg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DeckLegality checks whether a deck consisting of the main and side cards is
+// legal in the given format. Each entry counts as one copy. It returns the
+// verdict and a description of every offending card: cards which are banned
+// or not legal in the format, restricted cards with more than one copy and,
+// for Commander, non-basic cards with more than one copy.
+func DeckLegality(format string, main, side []*Card) (bool, []string, error) {
+	if strings.TrimSpace(format) == "" {
+		return false, nil, errors.New("No format given")
+	}
+	singleton := strings.EqualFold(format, "Commander")
+
+	var order []*Card
+	copies := make(map[string]int)
+	for _, card := range append(append([]*Card{}, main...), side...) {
+		if card == nil {
+			return false, nil, errors.New("Deck contains a nil card")
+		}
+		if copies[card.Name] == 0 {
+			order = append(order, card)
+		}
+		copies[card.Name]++
+	}
+
+	var problems []string
+	for _, card := range order {
+		n := copies[card.Name]
+		switch card.LegalityIn(format) {
+		case LegalityLegal:
+		case LegalityRestricted:
+			if n > 1 {
+				problems = append(problems, fmt.Sprintf("%s: restricted, %d copies", card.Name, n))
+				continue
+			}
+		case LegalityBanned:
+			problems = append(problems, fmt.Sprintf("%s: banned", card.Name))
+			continue
+		default:
+			problems = append(problems, fmt.Sprintf("%s: not legal", card.Name))
+			continue
+		}
+
+		if singleton && n > 1 && !allowsAnyNumber(card) {
+			problems = append(problems, fmt.Sprintf("%s: %d copies in a singleton format", card.Name, n))
+		}
+	}
+	return len(problems) == 0, problems, nil
+}
+
+// allowsAnyNumber reports whether a deck may contain any number of copies of
+// the card, as for basic lands and cards like Relentless Rats.
+func allowsAnyNumber(card *Card) bool {
+	return containsFold(card.Supertypes, "Basic") ||
+		strings.Contains(card.Text, "A deck can have any number of cards named")
+}
+
+// maxNamesQueryLen limits the length of the joined names sent in one request
+// by FetchCardsByNames, keeping the URL well below common server limits.
+const maxNamesQueryLen = 1500
+
+// FetchCardsByNames resolves the given card names, e.g. of a decklist, with
+// as few requests as possible. Names are combined with the OR delimiter into
+// batches of at most maxNamesQueryLen characters. As the API matches names
+// partially, the results are filtered for exact names, ignoring case.
+// The returned cards line up with names: cards[i] is a printing of names[i].
+// If names are not found, their entries are nil and the error lists them.
+func FetchCardsByNames(names ...string) ([]*Card, error) {
+	var batches [][]string
+	var batch []string
+	batchLen := 0
+	for _, name := range names {
+		for _, half := range strings.Split(name, "//") {
+			half = strings.TrimSpace(half)
+			if batchLen+len(half)+1 > maxNamesQueryLen && len(batch) > 0 {
+				batches = append(batches, batch)
+				batch, batchLen = nil, 0
+			}
+			batch = append(batch, half)
+			batchLen += len(half) + 1
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	var found []*Card
+	for _, batch := range batches {
+		cards, err := NewQuery().Where(CardName, Or.join(batch)).All()
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, cards...)
+	}
+
+	cards := make([]*Card, len(names))
+	var missing []string
+	for i, name := range names {
+		if matches := FilterCards(found, ByName(name)); len(matches) > 0 {
+			cards[i] = matches[0]
+		} else {
+			missing = append(missing, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(missing) > 0 {
+		return cards, fmt.Errorf("Cards not found: %s", strings.Join(missing, ", "))
+	}
+	return cards, nil
+}
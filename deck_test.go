@@ -0,0 +1,130 @@
+package mtg
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// legalIn builds a card with the given legality in each format, e.g.
+// legalIn("Sol Ring", "Commander", "Legal", "Vintage", "Restricted").
+func legalIn(name string, formatsAndLegalities ...string) *Card {
+	c := &Card{Name: name}
+	for i := 0; i+1 < len(formatsAndLegalities); i += 2 {
+		c.Legalities = append(c.Legalities, Legality{Format: formatsAndLegalities[i], Legality: formatsAndLegalities[i+1]})
+	}
+	return c
+}
+
+func TestDeckLegality(t *testing.T) {
+	forest := legalIn("Forest", "Commander", "Legal", "Vintage", "Legal")
+	forest.Supertypes = []string{"Basic"}
+	rats := legalIn("Relentless Rats", "Commander", "Legal")
+	rats.Text = "A deck can have any number of cards named Relentless Rats."
+	solRing := legalIn("Sol Ring", "Commander", "Legal", "Vintage", "Restricted")
+	lotus := legalIn("Black Lotus", "Commander", "Banned", "Vintage", "Restricted")
+	bolt := legalIn("Lightning Bolt", "Commander", "Legal", "Vintage", "Legal")
+	unset := legalIn("Goblin Lackey")
+
+	tests := []struct {
+		name   string
+		format string
+		main   []*Card
+		side   []*Card
+		want   []string
+	}{
+		{"legal", "Commander", []*Card{solRing, bolt, forest}, nil, nil},
+		{"singleton", "commander", []*Card{bolt, bolt}, nil, []string{"Lightning Bolt: 2 copies in a singleton format"}},
+		{"basic lands", "Commander", []*Card{forest, forest, forest}, nil, nil},
+		{"any number", "Commander", []*Card{rats, rats, rats}, nil, nil},
+		{"banned", "Commander", []*Card{lotus}, nil, []string{"Black Lotus: banned"}},
+		{"not legal", "Commander", []*Card{unset}, nil, []string{"Goblin Lackey: not legal"}},
+		{"no singleton outside Commander", "Vintage", []*Card{bolt, bolt, bolt, bolt}, nil, nil},
+		{"one restricted copy", "Vintage", []*Card{solRing, lotus}, nil, nil},
+		{"restricted copies", "Vintage", []*Card{solRing, solRing}, nil, []string{"Sol Ring: restricted, 2 copies"}},
+		{"restricted with sideboard", "Vintage", []*Card{lotus}, []*Card{lotus}, []string{"Black Lotus: restricted, 2 copies"}},
+		{"singleton with sideboard", "Commander", []*Card{solRing}, []*Card{solRing}, []string{"Sol Ring: 2 copies in a singleton format"}},
+		{"problems in deck order", "Commander", []*Card{unset, lotus, bolt, bolt}, nil, []string{
+			"Goblin Lackey: not legal",
+			"Black Lotus: banned",
+			"Lightning Bolt: 2 copies in a singleton format",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, problems, err := DeckLegality(tt.format, tt.main, tt.side)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != (len(tt.want) == 0) || !reflect.DeepEqual(problems, tt.want) {
+				t.Errorf("got %v, %q; want %v, %q", ok, problems, len(tt.want) == 0, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeckLegalityInvalid(t *testing.T) {
+	if _, _, err := DeckLegality(" ", nil, nil); err == nil {
+		t.Error("expected an error without a format")
+	}
+	if _, _, err := DeckLegality("Modern", []*Card{nil}, nil); err == nil {
+		t.Error("expected an error for a nil card")
+	}
+}
+
+func TestFetchCardsByNames(t *testing.T) {
+	var batches []int
+	stubAPI(t, func(r *http.Request) *http.Response {
+		names := strings.Split(r.URL.Query().Get("name"), "|")
+		batches = append(batches, len(names))
+
+		// Answer in reverse order and with a partial match, like the API may.
+		var cards []string
+		for i := len(names) - 1; i >= 0; i-- {
+			switch name := names[i]; name {
+			case "Missing Card":
+			case "Fire", "Ice":
+				cards = append(cards, fmt.Sprintf(`{"name":%q,"names":["Fire","Ice"]}`, name))
+			default:
+				cards = append(cards, fmt.Sprintf(`{"name":%q}`, name), fmt.Sprintf(`{"name":"%s Token"}`, name))
+			}
+		}
+		rec := httptest.NewRecorder()
+		rec.WriteString(`{"cards":[` + strings.Join(cards, ",") + `]}`)
+		return rec.Result()
+	})
+
+	var names []string
+	for i := 0; i < 20; i++ {
+		names = append(names, fmt.Sprintf("Card %02d %s", i, strings.Repeat("x", 100)))
+	}
+	names = append(names[:5], append([]string{"Missing Card", "Fire // Ice"}, names[5:]...)...)
+
+	cards, err := FetchCardsByNames(names...)
+	if err == nil || !strings.Contains(err.Error(), `"Missing Card"`) {
+		t.Errorf("got error %v, want one listing \"Missing Card\"", err)
+	}
+	if len(batches) < 2 {
+		t.Errorf("sent %d requests, want the names split into batches", len(batches))
+	}
+	if len(cards) != len(names) {
+		t.Fatalf("got %d cards for %d names", len(cards), len(names))
+	}
+	for i, name := range names {
+		switch {
+		case name == "Missing Card":
+			if cards[i] != nil {
+				t.Errorf("cards[%d] = %q, want nil for a missing name", i, cards[i].Name)
+			}
+		case name == "Fire // Ice":
+			if cards[i] == nil || !reflect.DeepEqual(cards[i].Names, []string{"Fire", "Ice"}) {
+				t.Errorf("cards[%d] = %+v, want Fire // Ice", i, cards[i])
+			}
+		case cards[i] == nil || cards[i].Name != name:
+			t.Errorf("cards[%d] = %+v, want %q", i, cards[i], name)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package mtg
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldDiff describes one Card field which differs between two cards.
+type FieldDiff struct {
+	// Field is the name of the Card field, e.g. "Artist".
+	Field string
+	// A is the value of the field in the first card.
+	A interface{}
+	// B is the value of the field in the second card.
+	B interface{}
+}
+
+// DiffCards returns the fields which differ between the two cards, in the
+// order they are declared in Card. Slices are compared element by element,
+// following pointers; a nil and an empty slice or map are considered equal.
+func DiffCards(a, b *Card) []FieldDiff {
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	t := va.Type()
+
+	var diffs []FieldDiff
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		fa, fb := va.Field(i), vb.Field(i)
+		if fieldsEqual(fa, fb) {
+			continue
+		}
+		diffs = append(diffs, FieldDiff{
+			Field: t.Field(i).Name,
+			A:     fa.Interface(),
+			B:     fb.Interface(),
+		})
+	}
+	return diffs
+}
+
+func fieldsEqual(a, b reflect.Value) bool {
+	if (a.Kind() == reflect.Slice || a.Kind() == reflect.Map) && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// EditOp is the kind of a TextEdit.
+type EditOp int
+
+const (
+	// EditEqual marks text present in both versions.
+	EditEqual EditOp = iota
+	// EditDelete marks text only present in the original version.
+	EditDelete
+	// EditInsert marks text only present in the new version.
+	EditInsert
+)
+
+// TextEdit is one run of words of a word-based diff.
+type TextEdit struct {
+	// Op tells whether the text was kept, deleted or inserted.
+	Op EditOp
+	// Text contains the words of the run.
+	Text string
+}
+
+// HasErrata reports whether the oracle text or type line differ from what
+// was printed. Only fields present in both versions are compared, as
+// OriginalText and OriginalType are missing for promo cards.
+func (c *Card) HasErrata() bool {
+	return (c.OriginalText != "" && c.Text != "" && c.OriginalText != c.Text) ||
+		(c.OriginalType != "" && c.Type != "" && c.OriginalType != c.Type)
+}
+
+// TextDiff returns a word-based diff from the printed OriginalText to the
+// oracle Text. It is empty if either text is missing. Whitespace is not
+// preserved; words of a run are joined with single spaces.
+func (c *Card) TextDiff() []TextEdit {
+	if c.OriginalText == "" || c.Text == "" {
+		return nil
+	}
+	return diffWords(strings.Fields(c.OriginalText), strings.Fields(c.Text))
+}
+
+// diffWords computes the longest common subsequence of a and b and returns
+// the edits turning a into b.
+func diffWords(a, b []string) []TextEdit {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var edits []TextEdit
+	add := func(op EditOp, word string) {
+		if n := len(edits); n > 0 && edits[n-1].Op == op {
+			edits[n-1].Text += " " + word
+			return
+		}
+		edits = append(edits, TextEdit{Op: op, Text: word})
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(EditEqual, a[i])
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(EditDelete, a[i])
+			i++
+		default:
+			add(EditInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(EditDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(EditInsert, b[j])
+	}
+	return edits
+}
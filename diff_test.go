@@ -0,0 +1,82 @@
+package mtg
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []TextEdit
+	}{
+		{
+			name: "unchanged",
+			a:    "Draw a card.",
+			b:    "Draw a card.",
+			want: []TextEdit{{EditEqual, "Draw a card."}},
+		},
+		{
+			name: "replaced word",
+			a:    "Target creature gets +3/+3.",
+			b:    "Target creature gets +2/+2.",
+			want: []TextEdit{
+				{EditEqual, "Target creature gets"},
+				{EditDelete, "+3/+3."},
+				{EditInsert, "+2/+2."},
+			},
+		},
+		{
+			name: "inserted words",
+			a:    "Destroy target creature.",
+			b:    "Destroy target creature. It can't be regenerated.",
+			want: []TextEdit{
+				{EditEqual, "Destroy target creature."},
+				{EditInsert, "It can't be regenerated."},
+			},
+		},
+		{
+			name: "deleted words",
+			a:    "Flying, first strike",
+			b:    "Flying,",
+			want: []TextEdit{
+				{EditEqual, "Flying,"},
+				{EditDelete, "first strike"},
+			},
+		},
+		{
+			name: "empty original",
+			a:    "",
+			b:    "Haste",
+			want: []TextEdit{{EditInsert, "Haste"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffWords(strings.Fields(tt.a), strings.Fields(tt.b))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffWords(%q, %q) = %+v, want %+v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextDiffRequiresBothTexts(t *testing.T) {
+	c := &Card{Text: "Flying"}
+	if edits := c.TextDiff(); edits != nil {
+		t.Errorf("got %+v without OriginalText, want nil", edits)
+	}
+}
+
+func TestDiffCards(t *testing.T) {
+	a := &Card{Name: "Shock", Artist: "Randy Gallegos", RawExtra: map[string]json.RawMessage{}}
+	b := &Card{Name: "Shock", Artist: "Jon Foster", Colors: []string{}}
+
+	diffs := DiffCards(a, b)
+	if len(diffs) != 1 || diffs[0].Field != "Artist" {
+		t.Errorf("got diffs %+v, want only Artist as empty slices and maps equal nil ones", diffs)
+	}
+}
@@ -0,0 +1,231 @@
+package mtg
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CardFilter reports whether a card should be kept by FilterCards.
+type CardFilter func(*Card) bool
+
+// FilterCards returns the cards which match all of the given filters.
+// The order of the cards is preserved.
+func FilterCards(cards []*Card, filters ...CardFilter) []*Card {
+	var result []*Card
+	for _, card := range cards {
+		if matchesAll(card, filters) {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+func matchesAll(card *Card, filters []CardFilter) bool {
+	for _, filter := range filters {
+		if !filter(card) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ByName matches cards with exactly the given name, ignoring case.
+// A split card also matches its full name such as "Fire // Ice".
+func ByName(name string) CardFilter {
+	name = strings.TrimSpace(name)
+	return func(c *Card) bool {
+		return strings.EqualFold(c.Name, name) ||
+			strings.EqualFold(strings.Join(c.Names, " // "), name)
+	}
+}
+
+// ByColor matches cards which have the given color, e.g. "Red".
+func ByColor(color string) CardFilter {
+	return func(c *Card) bool {
+		return containsFold(c.Colors, color)
+	}
+}
+
+// ByType matches cards which have the given type, e.g. "Creature".
+func ByType(cardType string) CardFilter {
+	return func(c *Card) bool {
+		return containsFold(c.Types, cardType)
+	}
+}
+
+// ByRarity matches cards of the given rarity, e.g. "Mythic Rare".
+func ByRarity(rarity string) CardFilter {
+	return func(c *Card) bool {
+		return strings.EqualFold(c.Rarity, rarity)
+	}
+}
+
+// ExcludeRarities matches cards whose rarity is none of the given ones,
+// e.g. ExcludeRarities(RarityCommon) for everything but commons. Rarities are
+// compared as normalized by ParseRarity. The API cannot negate filters, so
+// this is applied to fetched results.
+func ExcludeRarities(rarities ...Rarity) CardFilter {
+	excluded := make(map[Rarity]bool, len(rarities))
+	for _, rarity := range rarities {
+		excluded[ParseRarity(string(rarity))] = true
+	}
+	return func(c *Card) bool {
+		return !excluded[ParseRarity(c.Rarity)]
+	}
+}
+
+// ByReserved matches cards which are on the Reserved List if reserved is
+// true, or which are not if it is false. The API has no reserved filter, so
+// this is applied to fetched results; see ReservedListCards for the full list.
+func ByReserved(reserved bool) CardFilter {
+	return func(c *Card) bool {
+		return c.Reserved == reserved
+	}
+}
+
+// statFilter matches cards whose stat, read by get, parses as an integer
+// accepted by cmp. Variable stats such as "*" or "1+*" never match.
+func statFilter(get func(*Card) string, cmp func(int) bool) CardFilter {
+	return func(c *Card) bool {
+		n, err := strconv.Atoi(strings.TrimSpace(get(c)))
+		return err == nil && cmp(n)
+	}
+}
+
+func power(c *Card) string     { return c.Power }
+func toughness(c *Card) string { return c.Toughness }
+
+// ByPowerAtLeast matches creatures with a power of at least n.
+// The API cannot compare stats, so this is applied to fetched results.
+// Cards with a variable power such as "*" do not match.
+func ByPowerAtLeast(n int) CardFilter {
+	return statFilter(power, func(p int) bool { return p >= n })
+}
+
+// ByPowerAtMost matches creatures with a power of at most n.
+// Cards with a variable power such as "*" do not match.
+func ByPowerAtMost(n int) CardFilter {
+	return statFilter(power, func(p int) bool { return p <= n })
+}
+
+// ByToughnessAtLeast matches creatures with a toughness of at least n.
+// Cards with a variable toughness such as "*" do not match.
+func ByToughnessAtLeast(n int) CardFilter {
+	return statFilter(toughness, func(t int) bool { return t >= n })
+}
+
+// ByToughnessAtMost matches creatures with a toughness of at most n.
+// Cards with a variable toughness such as "*" do not match.
+func ByToughnessAtMost(n int) CardFilter {
+	return statFilter(toughness, func(t int) bool { return t <= n })
+}
+
+// colorSet returns the upper case codes of the given colors.
+func colorSet(ids []Color) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[strings.ToUpper(string(id))] = true
+	}
+	return set
+}
+
+// ByColorIdentityWithin matches cards whose color identity is a subset of
+// the given colors, i.e. the cards a commander of that identity may use.
+// Colorless cards always match.
+func ByColorIdentityWithin(ids ...Color) CardFilter {
+	allowed := colorSet(ids)
+	return func(c *Card) bool {
+		for _, code := range c.ColorIdentity {
+			if !allowed[strings.ToUpper(code)] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ByColorIdentityExact matches cards whose color identity consists of
+// exactly the given colors, in any order.
+func ByColorIdentityExact(ids ...Color) CardFilter {
+	want := colorSet(ids)
+	within := ByColorIdentityWithin(ids...)
+	return func(c *Card) bool {
+		return within(c) && len(colorSet(identityColors(c))) == len(want)
+	}
+}
+
+// identityColors returns the color identity of the card as Colors.
+func identityColors(c *Card) []Color {
+	colors := make([]Color, len(c.ColorIdentity))
+	for i, code := range c.ColorIdentity {
+		colors[i] = Color(code)
+	}
+	return colors
+}
+
+// ByCMCRange matches cards with a converted mana cost between min and max,
+// both inclusive.
+func ByCMCRange(min, max float64) CardFilter {
+	return func(c *Card) bool {
+		return c.CMC >= min && c.CMC <= max
+	}
+}
+
+// FilterOutTokens returns the cards which are not tokens.
+func FilterOutTokens(cards []*Card) []*Card {
+	return FilterCards(cards, func(c *Card) bool {
+		return !c.IsToken()
+	})
+}
+
+// ByNumberRange matches cards whose collector number lies between min and max,
+// both inclusive. Letter suffixes are ignored, so "12a" is within 10 to 20.
+// Cards without a numeric collector number never match.
+func ByNumberRange(min, max int) CardFilter {
+	return func(c *Card) bool {
+		n, ok := c.CollectorNumberInt()
+		return ok && n >= min && n <= max
+	}
+}
+
+// SortCardsByNumber sorts cards by collector number and then by suffix, so
+// "9" comes before "10" and "180a" before "180b". Cards without a numeric
+// collector number are sorted last.
+func SortCardsByNumber(cards []*Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		ni, oki := cards[i].CollectorNumberInt()
+		nj, okj := cards[j].CollectorNumberInt()
+		if oki != okj {
+			return oki
+		}
+		if ni != nj {
+			return ni < nj
+		}
+		return cards[i].CollectorNumberSuffix() < cards[j].CollectorNumberSuffix()
+	})
+}
+
+// CardsInNumberRange returns the cards of a set whose collector number lies
+// between min and max, sorted by collector number. The API only supports
+// exact number matches, so the set's cards are fetched and filtered locally.
+func CardsInNumberRange(code SetCode, min, max int) ([]*Card, error) {
+	cards, err := NewQuery().WhereSet(code).All()
+	if err != nil {
+		return nil, err
+	}
+
+	cards = FilterCards(cards, ByNumberRange(min, max))
+	SortCardsByNumber(cards)
+	return cards, nil
+}
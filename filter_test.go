@@ -0,0 +1,17 @@
+package mtg
+
+import "testing"
+
+func TestExcludeRarities(t *testing.T) {
+	cards := []*Card{
+		{Name: "a", Rarity: "Common"},
+		{Name: "b", Rarity: "mythic rare"},
+		{Name: "c", Rarity: "Rare"},
+		{Name: "d", Rarity: "Basic Land"},
+	}
+
+	got := FilterCards(cards, ExcludeRarities(RarityCommon, RarityMythicRare, Rarity("basic land")))
+	if len(got) != 1 || got[0].Name != "c" {
+		t.Errorf("got %d cards, want only the rare", len(got))
+	}
+}
@@ -0,0 +1,181 @@
+package mtg
+
+import "strings"
+
+// Delimiters used by FlatCard to join list fields.
+const (
+	// FlatListSep joins lists of simple values such as colors and set codes,
+	// none of which contain a comma.
+	FlatListSep = ","
+	// FlatTypesSep joins types, supertypes and subtypes, following the pipe
+	// the API uses to combine type filters.
+	FlatTypesSep = "|"
+	// FlatNamesSep joins the names of split, flip and double-faced cards, as
+	// card names may contain commas. It matches the printed "A // B" style.
+	FlatNamesSep = " // "
+	// FlatLegalitySep joins the legalities, each written as "Format:Legality".
+	FlatLegalitySep = ";"
+)
+
+// FlatCard is a Card without nested values, suitable for a single CSV row or
+// database table. List fields are joined into strings: Names with
+// FlatNamesSep, Types, Supertypes and Subtypes with FlatTypesSep,
+// Legalities with FlatLegalitySep and all other lists with FlatListSep.
+// Empty lists become empty strings. Rulings, ForeignNames and RawExtra are
+// not kept.
+type FlatCard struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Names         string  `json:"names"`
+	ManaCost      string  `json:"manaCost"`
+	CMC           float64 `json:"cmc"`
+	Colors        string  `json:"colors"`
+	ColorIdentity string  `json:"colorIdentity"`
+	Type          string  `json:"type"`
+	Types         string  `json:"types"`
+	Supertypes    string  `json:"supertypes"`
+	Subtypes      string  `json:"subtypes"`
+	Rarity        string  `json:"rarity"`
+	Set           SetCode `json:"set"`
+	SetName       string  `json:"setName"`
+	Text          string  `json:"text"`
+	Flavor        string  `json:"flavor"`
+	Artist        string  `json:"artist"`
+	Number        string  `json:"number"`
+	Power         string  `json:"power"`
+	Toughness     string  `json:"toughness"`
+	Loyalty       string  `json:"loyalty"`
+	Layout        string  `json:"layout"`
+	MultiverseID  string  `json:"multiverseid"`
+	Variations    string  `json:"variations"`
+	ImageURL      string  `json:"imageUrl"`
+	Watermark     string  `json:"watermark"`
+	Border        string  `json:"border"`
+	Timeshifted   bool    `json:"timeshifted"`
+	Hand          int     `json:"hand"`
+	Life          int     `json:"life"`
+	Reserved      bool    `json:"reserved"`
+	ReleaseDate   string  `json:"releaseDate"`
+	Starter       bool    `json:"starter"`
+	Printings     string  `json:"printings"`
+	OriginalText  string  `json:"originalText"`
+	OriginalType  string  `json:"originalType"`
+	Source        string  `json:"source"`
+	Legalities    string  `json:"legalities"`
+}
+
+// Flatten converts the card to a FlatCard.
+func (c *Card) Flatten() FlatCard {
+	printings := make([]string, len(c.Printings))
+	for i, code := range c.Printings {
+		printings[i] = string(code)
+	}
+	legalities := make([]string, len(c.Legalities))
+	for i, l := range c.Legalities {
+		legalities[i] = l.Format + ":" + l.Legality
+	}
+
+	return FlatCard{
+		ID:            c.ID,
+		Name:          c.Name,
+		Names:         strings.Join(c.Names, FlatNamesSep),
+		ManaCost:      c.ManaCost,
+		CMC:           c.CMC,
+		Colors:        strings.Join(c.Colors, FlatListSep),
+		ColorIdentity: strings.Join(c.ColorIdentity, FlatListSep),
+		Type:          c.Type,
+		Types:         strings.Join(c.Types, FlatTypesSep),
+		Supertypes:    strings.Join(c.Supertypes, FlatTypesSep),
+		Subtypes:      strings.Join(c.Subtypes, FlatTypesSep),
+		Rarity:        c.Rarity,
+		Set:           c.Set,
+		SetName:       c.SetName,
+		Text:          c.Text,
+		Flavor:        c.Flavor,
+		Artist:        c.Artist,
+		Number:        c.Number,
+		Power:         c.Power,
+		Toughness:     c.Toughness,
+		Loyalty:       c.Loyalty,
+		Layout:        c.Layout,
+		MultiverseID:  c.MultiverseID,
+		Variations:    strings.Join(c.Variations, FlatListSep),
+		ImageURL:      c.ImageURL,
+		Watermark:     c.Watermark,
+		Border:        c.Border,
+		Timeshifted:   c.Timeshifted,
+		Hand:          c.Hand,
+		Life:          c.Life,
+		Reserved:      c.Reserved,
+		ReleaseDate:   c.ReleaseDate,
+		Starter:       c.Starter,
+		Printings:     strings.Join(printings, FlatListSep),
+		OriginalText:  c.OriginalText,
+		OriginalType:  c.OriginalType,
+		Source:        c.Source,
+		Legalities:    strings.Join(legalities, FlatLegalitySep),
+	}
+}
+
+// Unflatten converts the FlatCard back to a Card. It reverses Flatten except
+// for the fields Flatten drops; empty list fields become nil slices.
+func (f FlatCard) Unflatten() *Card {
+	var printings []SetCode
+	for _, code := range splitFlat(f.Printings, FlatListSep) {
+		printings = append(printings, SetCode(code))
+	}
+	var legalities []Legality
+	for _, l := range splitFlat(f.Legalities, FlatLegalitySep) {
+		format, legality, _ := strings.Cut(l, ":")
+		legalities = append(legalities, Legality{Format: format, Legality: legality})
+	}
+
+	return &Card{
+		ID:            f.ID,
+		Name:          f.Name,
+		Names:         splitFlat(f.Names, FlatNamesSep),
+		ManaCost:      f.ManaCost,
+		CMC:           f.CMC,
+		Colors:        splitFlat(f.Colors, FlatListSep),
+		ColorIdentity: splitFlat(f.ColorIdentity, FlatListSep),
+		Type:          f.Type,
+		Types:         splitFlat(f.Types, FlatTypesSep),
+		Supertypes:    splitFlat(f.Supertypes, FlatTypesSep),
+		Subtypes:      splitFlat(f.Subtypes, FlatTypesSep),
+		Rarity:        f.Rarity,
+		Set:           f.Set,
+		SetName:       f.SetName,
+		Text:          f.Text,
+		Flavor:        f.Flavor,
+		Artist:        f.Artist,
+		Number:        f.Number,
+		Power:         f.Power,
+		Toughness:     f.Toughness,
+		Loyalty:       f.Loyalty,
+		Layout:        f.Layout,
+		MultiverseID:  f.MultiverseID,
+		Variations:    splitFlat(f.Variations, FlatListSep),
+		ImageURL:      f.ImageURL,
+		Watermark:     f.Watermark,
+		Border:        f.Border,
+		Timeshifted:   f.Timeshifted,
+		Hand:          f.Hand,
+		Life:          f.Life,
+		Reserved:      f.Reserved,
+		ReleaseDate:   f.ReleaseDate,
+		Starter:       f.Starter,
+		Printings:     printings,
+		OriginalText:  f.OriginalText,
+		OriginalType:  f.OriginalType,
+		Source:        f.Source,
+		Legalities:    legalities,
+	}
+}
+
+// splitFlat splits a joined FlatCard field, returning nil for an empty one.
+func splitFlat(s, sep string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, sep)
+}
@@ -0,0 +1,57 @@
+package mtg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenRoundTrip(t *testing.T) {
+	card := &Card{
+		ID:            "c1",
+		Name:          "Fire",
+		Names:         []string{"Fire", "Ice"},
+		ManaCost:      "{1}{R}",
+		CMC:           2,
+		Colors:        []string{"Red"},
+		ColorIdentity: []string{"R", "U"},
+		Type:          "Tribal Instant — Elemental",
+		Types:         []string{"Tribal", "Instant"},
+		Subtypes:      []string{"Elemental"},
+		Rarity:        "Uncommon",
+		Set:           "APC",
+		Text:          "Fire deals 2 damage divided as you choose among one or two targets.",
+		Number:        "128a",
+		Layout:        "split",
+		Variations:    []string{"1", "2"},
+		Printings:     []SetCode{"APC", "MMA"},
+		Legalities: []Legality{
+			{Format: "Modern", Legality: "Legal"},
+			{Format: "Vintage", Legality: "Legal"},
+		},
+	}
+
+	flat := card.Flatten()
+	if flat.Names != "Fire // Ice" || flat.ColorIdentity != "R,U" || flat.Types != "Tribal|Instant" ||
+		flat.Printings != "APC,MMA" ||
+		flat.Legalities != "Modern:Legal;Vintage:Legal" {
+		t.Errorf("unexpected flat list fields: %+v", flat)
+	}
+
+	if got := flat.Unflatten(); !reflect.DeepEqual(got, card) {
+		t.Errorf("Unflatten(Flatten()) = %+v, want %+v", got, card)
+	}
+}
+
+func TestFlattenNameWithComma(t *testing.T) {
+	card := &Card{Name: "Borrowing 100,000 Arrows", Names: []string{"Borrowing 100,000 Arrows"}}
+	if got := card.Flatten().Unflatten().Names; !reflect.DeepEqual(got, card.Names) {
+		t.Errorf("got names %q, want %q", got, card.Names)
+	}
+}
+
+func TestUnflattenEmptyLists(t *testing.T) {
+	c := FlatCard{Name: "Wastes"}.Unflatten()
+	if c.Colors != nil || c.Types != nil || c.Printings != nil || c.Legalities != nil {
+		t.Errorf("empty list fields should be nil, got %+v", c)
+	}
+}
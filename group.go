@@ -0,0 +1,69 @@
+package mtg
+
+// ColorlessIdentity is the GroupByColorIdentity key for colorless cards.
+const ColorlessIdentity = "C"
+
+// GroupByColorIdentity groups cards by their color identity. The keys are
+// the canonical WUBRG strings of Card.ColorIdentityString, e.g. "WU" or
+// "BRG"; cards without a color identity are grouped under ColorlessIdentity.
+func GroupByColorIdentity(cards []*Card) map[string][]*Card {
+	groups := make(map[string][]*Card)
+	for _, card := range cards {
+		key := card.ColorIdentityString()
+		if key == "" {
+			key = ColorlessIdentity
+		}
+		groups[key] = append(groups[key], card)
+	}
+	return groups
+}
+
+// CountByType counts the cards per type, e.g. "Creature" or "Instant".
+// Cards with several types, such as artifact creatures, count towards each.
+func CountByType(cards []*Card) map[string]int {
+	counts := make(map[string]int)
+	for _, card := range cards {
+		for _, t := range card.Types {
+			counts[t]++
+		}
+	}
+	return counts
+}
+
+// NoBlock is the GroupSetsByBlock key for sets which are not part of a block.
+const NoBlock = ""
+
+// GroupSetsByBlock groups sets by their block, each group sorted by release
+// date. Sets without a block are grouped under NoBlock.
+func GroupSetsByBlock(sets []*Set) map[string][]*Set {
+	groups := make(map[string][]*Set)
+	for _, set := range sets {
+		groups[set.Block] = append(groups[set.Block], set)
+	}
+	for _, group := range groups {
+		SortSetsByReleaseDate(group)
+	}
+	return groups
+}
+
+// BlocksInOrder returns the blocks of the given sets, ordered by the release
+// date of their earliest set. Blocks without a valid release date come last,
+// ties are ordered by block name. NoBlock is left out.
+func BlocksInOrder(sets []*Set) []string {
+	var firsts []*Set
+	for block, group := range GroupSetsByBlock(sets) {
+		if block == NoBlock {
+			continue
+		}
+		// Compare blocks through a Set carrying the block name and the
+		// release date of its earliest set.
+		firsts = append(firsts, &Set{Name: block, ReleaseDate: group[0].ReleaseDate})
+	}
+	SortSetsByReleaseDate(firsts)
+
+	blocks := make([]string, len(firsts))
+	for i, first := range firsts {
+		blocks[i] = first.Name
+	}
+	return blocks
+}
@@ -0,0 +1,193 @@
+package mtg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// ImageSize selects the resolution of a card image.
+type ImageSize string
+
+// Image sizes supported by ImageURLForSize.
+const (
+	// ImageSmall is a small JPEG, suitable for thumbnails.
+	ImageSmall = ImageSize("small")
+	// ImageNormal is a medium sized JPEG.
+	ImageNormal = ImageSize("normal")
+	// ImageLarge is a large JPEG.
+	ImageLarge = ImageSize("large")
+	// ImagePNG is a high resolution PNG with transparent corners.
+	ImagePNG = ImageSize("png")
+)
+
+// ImageFormat is the encoding of a downloaded card image.
+type ImageFormat string
+
+// Image formats recognized by DetectImageFormat.
+const (
+	ImageFormatJPEG = ImageFormat("jpeg")
+	ImageFormatPNG  = ImageFormat("png")
+)
+
+var (
+	jpegMagic = []byte{0xFF, 0xD8, 0xFF}
+	pngMagic  = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// DetectImageFormat returns the format of img by its magic bytes. It returns
+// an error if img is neither a JPEG nor a PNG, e.g. if the server answered
+// with an HTML error page instead of the image.
+func DetectImageFormat(img []byte) (ImageFormat, error) {
+	switch {
+	case bytes.HasPrefix(img, jpegMagic):
+		return ImageFormatJPEG, nil
+	case bytes.HasPrefix(img, pngMagic):
+		return ImageFormatPNG, nil
+	}
+
+	trimmed := bytes.ToLower(bytes.TrimSpace(img))
+	if bytes.HasPrefix(trimmed, []byte("<!doctype html")) || bytes.HasPrefix(trimmed, []byte("<html")) {
+		return "", errors.New("Received an HTML page instead of an image")
+	}
+	if len(img) == 0 {
+		return "", errors.New("Received an empty image")
+	}
+	return "", errors.New("Received data which is neither a JPEG nor a PNG image")
+}
+
+// ImageURLForSize returns the URL of the card image in the given size.
+// ImageURL only offers Gatherer's single resolution, so the URL points to
+// Scryfall's image endpoint for the card's MultiverseID, which redirects to
+// the image. The bool is false for cards without a MultiverseID and for
+// unknown sizes.
+func (c *Card) ImageURLForSize(size ImageSize) (string, bool) {
+	switch size {
+	case ImageSmall, ImageNormal, ImageLarge, ImagePNG:
+	default:
+		return "", false
+	}
+	if c.MultiverseID == "" {
+		return "", false
+	}
+	return fmt.Sprintf("https://api.scryfall.com/cards/multiverse/%s?format=image&version=%s",
+		url.PathEscape(c.MultiverseID), size), true
+}
+
+// ImageBytesIfModified downloads the image of the card unless it still
+// matches the given etag. Pass an empty etag to always download the image.
+// It returns the image, its current ETag and whether the server answered
+// with 304 Not Modified. If notModified is true no image is returned and the
+// given etag stays valid. Downloaded bytes are checked with DetectImageFormat,
+// so an error page is never returned as an image.
+func (c *Card) ImageBytesIfModified(ctx context.Context, etag string) (img []byte, newETag string, notModified bool, err error) {
+	img, _, newETag, notModified, err = c.fetchImage(ctx, etag)
+	return img, newETag, notModified, err
+}
+
+// ImageWithFormat downloads the image of the card and returns it together
+// with its format as detected by DetectImageFormat.
+func (c *Card) ImageWithFormat(ctx context.Context) ([]byte, ImageFormat, error) {
+	img, format, _, _, err := c.fetchImage(ctx, "")
+	return img, format, err
+}
+
+// fetchImage downloads and validates the image of the card unless it still
+// matches the given etag.
+func (c *Card) fetchImage(ctx context.Context, etag string) (img []byte, format ImageFormat, newETag string, notModified bool, err error) {
+	if c.ImageURL == "" {
+		return nil, "", "", false, fmt.Errorf("Card %q has no image", c.Name)
+	}
+
+	req, err := newRequest(ctx, c.ImageURL)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, "", etag, true, nil
+	}
+	if err := checkError(resp); err != nil {
+		return nil, "", "", false, err
+	}
+
+	img, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	format, err = DetectImageFormat(img)
+	if err != nil {
+		return nil, "", "", false, fmt.Errorf("Card %q: %w", c.Name, err)
+	}
+
+	return img, format, resp.Header.Get("ETag"), false, nil
+}
+
+// PrefetchImages downloads the images of the given cards in parallel, using
+// at most concurrency simultaneous requests. The result maps card IDs to
+// their image. Cards without an ImageURL are skipped. Failed downloads do not
+// stop the others; their errors are joined into the returned error, next to
+// the images which were downloaded successfully. Once ctx is done no further
+// downloads are started and ctx.Err() is returned instead.
+func PrefetchImages(ctx context.Context, cards []*Card, concurrency int) (map[string][]byte, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		images = make(map[string][]byte)
+		errs   []error
+		sem    = make(chan struct{}, concurrency)
+	)
+	for _, card := range cards {
+		if card.ImageURL == "" {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(card *Card) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			img, _, _, err := card.ImageBytesIfModified(ctx, "")
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Card %s: %w", card.ID, err))
+				return
+			}
+			images[card.ID] = img
+		}(card)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return images, err
+	}
+	return images, errors.Join(errs...)
+}
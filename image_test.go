@@ -0,0 +1,90 @@
+package mtg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDetectImageFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    ImageFormat
+		wantErr bool
+	}{
+		{"jpeg", "\xFF\xD8\xFF\xE0rest", ImageFormatJPEG, false},
+		{"png", "\x89PNG\r\n\x1a\nrest", ImageFormatPNG, false},
+		{"html", "  <!DOCTYPE html><html><body>Not found</body></html>", "", true},
+		{"html without doctype", "<HTML>error</HTML>", "", true},
+		{"empty", "", "", true},
+		{"gif", "GIF89a", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectImageFormat([]byte(tt.data))
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("DetectImageFormat = %q, %v; want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageWithFormat(t *testing.T) {
+	stubAPI(t, func(r *http.Request) *http.Response {
+		rec := httptest.NewRecorder()
+		rec.WriteString("\x89PNG\r\n\x1a\nimage")
+		return rec.Result()
+	})
+
+	c := &Card{Name: "Forest", ImageURL: "https://example.com/forest.png"}
+	img, format, err := c.ImageWithFormat(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != ImageFormatPNG || len(img) == 0 {
+		t.Errorf("got %d bytes of %q, want a PNG", len(img), format)
+	}
+}
+
+func TestImageWithFormatRejectsHTML(t *testing.T) {
+	stubAPI(t, func(r *http.Request) *http.Response {
+		rec := httptest.NewRecorder()
+		rec.WriteString("<html>missing image</html>")
+		return rec.Result()
+	})
+
+	c := &Card{Name: "Forest", ImageURL: "https://example.com/forest.png"}
+	if _, _, err := c.ImageWithFormat(context.Background()); err == nil {
+		t.Error("expected an error for an HTML page")
+	}
+}
+
+func TestPrefetchImagesStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := 0
+	stubAPI(t, func(r *http.Request) *http.Response {
+		requests++
+		cancel()
+		rec := httptest.NewRecorder()
+		rec.WriteString("\x89PNG\r\n\x1a\nimage")
+		return rec.Result()
+	})
+
+	cards := []*Card{
+		{ID: "a", ImageURL: "https://example.com/a.png"},
+		{ID: "b", ImageURL: "https://example.com/b.png"},
+		{ID: "c", ImageURL: "https://example.com/c.png"},
+	}
+	_, err := PrefetchImages(ctx, cards, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, want none after the cancellation", requests)
+	}
+}
@@ -0,0 +1,62 @@
+package mtg
+
+import "strings"
+
+// setNumber identifies one printing by its set and collector number.
+type setNumber struct {
+	set    SetCode
+	number string
+}
+
+// Index provides constant time lookups of loaded cards. The zero value is an
+// empty Index ready to use.
+// It is not safe for concurrent use while cards are being added.
+type Index struct {
+	byName         map[string][]*Card
+	bySetNumber    map[setNumber]*Card
+	byMultiverseID map[string]*Card
+}
+
+// NewIndex returns an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		byName:         make(map[string][]*Card),
+		bySetNumber:    make(map[setNumber]*Card),
+		byMultiverseID: make(map[string]*Card),
+	}
+}
+
+// AddCards adds the given cards to the index.
+func (idx *Index) AddCards(cards []*Card) {
+	if idx.byName == nil {
+		*idx = *NewIndex()
+	}
+	for _, card := range cards {
+		name := strings.ToLower(card.Name)
+		idx.byName[name] = append(idx.byName[name], card)
+		if card.Number != "" {
+			idx.bySetNumber[setNumber{card.Set.Normalize(), card.Number}] = card
+		}
+		if card.MultiverseID != "" {
+			idx.byMultiverseID[card.MultiverseID] = card
+		}
+	}
+}
+
+// ByName returns all printings with the given name, ignoring case.
+func (idx *Index) ByName(name string) []*Card {
+	return idx.byName[strings.ToLower(name)]
+}
+
+// BySetNumber returns the card with the given collector number in a set.
+// The set code is compared as normalized by SetCode.Normalize.
+func (idx *Index) BySetNumber(set SetCode, number string) (*Card, bool) {
+	card, ok := idx.bySetNumber[setNumber{set.Normalize(), number}]
+	return card, ok
+}
+
+// ByMultiverseID returns the card with the given MultiverseID.
+func (idx *Index) ByMultiverseID(id string) (*Card, bool) {
+	card, ok := idx.byMultiverseID[id]
+	return card, ok
+}
@@ -0,0 +1,21 @@
+package mtg
+
+import "testing"
+
+func TestIndexZeroValue(t *testing.T) {
+	var idx Index
+	if _, ok := idx.ByMultiverseID("1"); ok {
+		t.Error("empty index found a card")
+	}
+
+	idx.AddCards([]*Card{{Name: "Forest", Set: "LEA", Number: "1", MultiverseID: "288"}})
+	if got := idx.ByName("forest"); len(got) != 1 {
+		t.Errorf("ByName found %d cards, want 1", len(got))
+	}
+	if _, ok := idx.BySetNumber("LEA", "1"); !ok {
+		t.Error("BySetNumber did not find the card")
+	}
+	if _, ok := idx.ByMultiverseID("288"); !ok {
+		t.Error("ByMultiverseID did not find the card")
+	}
+}
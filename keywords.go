@@ -0,0 +1,157 @@
+package mtg
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// keywordAbilities lists the keyword abilities recognized by Card.Keywords.
+var keywordAbilities = []string{
+	"Absorb", "Affinity", "Afflict", "Afterlife", "Aftermath", "Amplify",
+	"Annihilator", "Ascend", "Aura swap", "Awaken", "Backup", "Banding",
+	"Battle cry", "Bestow", "Blitz", "Bloodthirst", "Boast", "Bushido",
+	"Buyback", "Cascade", "Casualty", "Champion", "Changeling", "Cipher",
+	"Cleave", "Companion", "Compleated", "Conspire", "Convoke", "Crew",
+	"Cumulative upkeep", "Cycling", "Dash", "Daybound", "Deathtouch",
+	"Decayed", "Defender", "Delve", "Demonstrate", "Dethrone", "Devoid",
+	"Devour", "Disturb", "Double strike", "Dredge", "Echo", "Embalm",
+	"Emerge", "Enchant", "Encore", "Enlist", "Entwine", "Epic", "Equip",
+	"Escape", "Eternalize", "Evoke", "Evolve", "Exalted", "Exploit",
+	"Extort", "Fabricate", "Fading", "Fear", "First strike", "Flanking",
+	"Flash", "Flashback", "Flying", "Forecast", "Foretell", "Fortify",
+	"Frenzy", "Fuse", "Graft", "Gravestorm", "Haste", "Haunt", "Hexproof",
+	"Hidden agenda", "Hideaway", "Horsemanship", "Improvise", "Indestructible",
+	"Infect", "Ingest", "Intimidate", "Jump-start", "Kicker", "Landwalk",
+	"Level up", "Lifelink", "Living weapon", "Madness", "Melee", "Menace",
+	"Mentor", "Miracle", "Modular", "Morph", "Multikicker", "Mutate",
+	"Myriad", "Nightbound", "Ninjutsu", "Offering", "Outlast", "Overload",
+	"Partner", "Persist", "Phasing", "Poisonous", "Protection", "Provoke",
+	"Prowess", "Prowl", "Rampage", "Ravenous", "Reach", "Read ahead",
+	"Rebound", "Reconfigure", "Recover", "Reinforce", "Renown", "Replicate",
+	"Retrace", "Riot", "Ripple", "Scavenge", "Shadow", "Shroud", "Skulk",
+	"Soulbond", "Soulshift", "Spectacle", "Splice", "Split second",
+	"Squad", "Storm", "Sunburst", "Surge", "Suspend", "Toxic", "Training",
+	"Trample", "Transfigure", "Transmute", "Tribute", "Undaunted",
+	"Undying", "Unearth", "Unleash", "Vanishing", "Vigilance", "Ward",
+	"Wither",
+}
+
+// keywordsByLength holds keywordAbilities longest first, so "Flashback" is
+// tried before "Flash".
+var keywordsByLength = func() []string {
+	kws := append([]string(nil), keywordAbilities...)
+	sort.SliceStable(kws, func(i, j int) bool { return len(kws[i]) > len(kws[j]) })
+	return kws
+}()
+
+// reminderTextRE matches parenthesized reminder text.
+var reminderTextRE = regexp.MustCompile(`\([^)]*\)`)
+
+// Keywords returns the keyword abilities of the card, such as "Flying" or
+// "Protection", in the order they appear in Text. Reminder text is ignored,
+// as are keywords mentioned within other abilities, e.g. "Target creature
+// gains flying": only lines listing keywords, optionally followed by their
+// cost or parameter as in "Equip {2}" or "Protection from red", count.
+// Land types such as "Swampwalk" are reported as "Landwalk".
+func (c *Card) Keywords() []string {
+	var keywords []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(reminderTextRE.ReplaceAllString(c.Text, ""), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var found []string
+		for _, part := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ';' }) {
+			part = strings.TrimSpace(part)
+			kw := leadingKeyword(part)
+			if kw == "" {
+				found = nil
+				break
+			}
+			found = append(found, kw)
+			if takesLineParam(kw, part) {
+				break
+			}
+		}
+
+		for _, kw := range found {
+			if !seen[kw] {
+				seen[kw] = true
+				keywords = append(keywords, kw)
+			}
+		}
+	}
+	return keywords
+}
+
+// lineParamKeywords are the keyword abilities whose parameter runs to the end
+// of the line and may contain commas, e.g. "Partner with Pir, Imaginative
+// Rascal" or "Protection from white, from blue".
+var lineParamKeywords = map[string]bool{
+	"Affinity":   true,
+	"Champion":   true,
+	"Enchant":    true,
+	"Hexproof":   true,
+	"Partner":    true,
+	"Protection": true,
+	"Splice":     true,
+}
+
+// takesLineParam reports whether part starts with keyword kw followed by a
+// parameter which runs to the end of the line.
+func takesLineParam(kw, part string) bool {
+	return lineParamKeywords[kw] && len(part) > len(kw) && part[len(kw)] == ' '
+}
+
+// keywordParamPrefixes are the words which may follow a keyword ability as
+// its parameter, e.g. "Protection from red" or "Affinity for artifacts".
+var keywordParamPrefixes = []string{"{", "x", "from ", "with ", "for ", "onto ", "a ", "an "}
+
+// leadingKeyword returns the keyword ability the text starts with, or an
+// empty string if it starts with none or the keyword is part of a sentence
+// such as "Flying creatures you control get +1/+1."
+func leadingKeyword(text string) string {
+	lower := strings.ToLower(text)
+	for _, kw := range keywordsByLength {
+		rest, ok := strings.CutPrefix(lower, strings.ToLower(kw))
+		if ok && isKeywordRest(kw, rest) {
+			return kw
+		}
+	}
+	if word, rest, _ := strings.Cut(lower, " "); strings.HasSuffix(word, "walk") && len(word) > len("walk") {
+		if rest == "" || isKeywordRest("Landwalk", " "+rest) {
+			return "Landwalk"
+		}
+	}
+	return ""
+}
+
+// isKeywordRest reports whether rest, the text following keyword kw, is
+// empty or a parameter of the keyword rather than the rest of a sentence.
+func isKeywordRest(kw, rest string) bool {
+	if rest == "" || rest == " " {
+		return true
+	}
+	r := []rune(rest)[0]
+	if r != ' ' {
+		return !unicode.IsLetter(r) && r != '-'
+	}
+
+	rest = rest[1:]
+	if kw == "Enchant" {
+		return true
+	}
+	if r := []rune(rest)[0]; unicode.IsDigit(r) || r == '—' {
+		return true
+	}
+	for _, prefix := range keywordParamPrefixes {
+		if strings.HasPrefix(rest, prefix) && (prefix != "x" || len(rest) == 1 || !unicode.IsLetter([]rune(rest)[1])) {
+			return true
+		}
+	}
+	return false
+}
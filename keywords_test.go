@@ -0,0 +1,40 @@
+package mtg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCardKeywords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"list", "Flying, vigilance", []string{"Flying", "Vigilance"}},
+		{"lines", "Flash\nFlying", []string{"Flash", "Flying"}},
+		{"longest match", "Flashback {2}{R}", []string{"Flashback"}},
+		{"two words", "First strike, double strike", []string{"First strike", "Double strike"}},
+		{"reminder text", "Kicker {1}{G} (You may pay an additional {1}{G} as you cast this spell. It has flying.)", []string{"Kicker"}},
+		{"granted keyword", "Target creature gains flying until end of turn.", nil},
+		{"sentence", "Flying creatures you control get +1/+1.", nil},
+		{"parameter", "Protection from red\nEquip {2}", []string{"Protection", "Equip"}},
+		{"number", "Toxic 2", []string{"Toxic"}},
+		{"em dash cost", "Ward—Pay 2 life.", []string{"Ward"}},
+		{"landwalk", "Swampwalk", []string{"Landwalk"}},
+		{"enchant", "Enchant creature\nEnchanted creature has trample.", []string{"Enchant"}},
+		{"parameter with comma", "Partner with Pir, Imaginative Rascal", []string{"Partner"}},
+		{"protection list", "Flying, protection from white, from blue", []string{"Flying", "Protection"}},
+		{"keyword then parameterless keyword", "Hexproof, indestructible", []string{"Hexproof", "Indestructible"}},
+		{"duplicates", "Flying\nFlying", []string{"Flying"}},
+		{"no text", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Card{Text: tt.text}
+			if got := c.Keywords(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keywords() of %q = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package mtg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Color is a color code as used in ColorIdentity and mana symbols.
+type Color string
+
+// The five colors of Magic.
+const (
+	ColorWhite = Color("W")
+	ColorBlue  = Color("U")
+	ColorBlack = Color("B")
+	ColorRed   = Color("R")
+	ColorGreen = Color("G")
+)
+
+// ManaSymbol is one symbol of a mana cost, such as {2}, {W/U} or {B/P}.
+type ManaSymbol struct {
+	// Text is the symbol without braces, e.g. "2", "W/U" or "B/P".
+	Text string
+}
+
+// String returns the symbol in braces as printed in a mana cost.
+func (m ManaSymbol) String() string {
+	return "{" + m.Text + "}"
+}
+
+// parts returns the halves of the symbol, split at the slashes.
+func (m ManaSymbol) parts() []string {
+	return strings.Split(m.Text, "/")
+}
+
+// Generic returns the amount of generic mana of symbols like {3}.
+// The bool is false for all other symbols.
+func (m ManaSymbol) Generic() (int, bool) {
+	n, err := strconv.Atoi(m.Text)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// IsVariable reports whether the symbol is a variable amount like {X}.
+func (m ManaSymbol) IsVariable() bool {
+	return m.Text == "X" || m.Text == "Y" || m.Text == "Z"
+}
+
+// IsHybrid reports whether the symbol can be paid in more than one way,
+// e.g. {W/U} or {2/B}. Phyrexian symbols of a single color such as {R/P}
+// are not hybrid.
+func (m ManaSymbol) IsHybrid() bool {
+	n := 0
+	for _, p := range m.parts() {
+		if p != "P" {
+			n++
+		}
+	}
+	return n > 1
+}
+
+// IsPhyrexian reports whether the symbol can be paid with life, e.g. {G/P}.
+func (m ManaSymbol) IsPhyrexian() bool {
+	for _, p := range m.parts() {
+		if p == "P" {
+			return true
+		}
+	}
+	return false
+}
+
+// Colors returns the color codes (W, U, B, R or G) of the symbol in WUBRG
+// order. Generic, colorless and variable symbols have no colors.
+func (m ManaSymbol) Colors() []string {
+	var colors []string
+	for _, c := range wubrg {
+		if strings.ContainsRune(m.Text, c) {
+			colors = append(colors, string(c))
+		}
+	}
+	return colors
+}
+
+// ParseManaCost splits a mana cost like "{2}{W/U}{B/P}" into its symbols.
+func ParseManaCost(cost string) ([]ManaSymbol, error) {
+	var symbols []ManaSymbol
+	rest := strings.TrimSpace(cost)
+	for rest != "" {
+		end := strings.IndexByte(rest, '}')
+		if rest[0] != '{' || end < 2 {
+			return nil, fmt.Errorf("Invalid mana cost %q", cost)
+		}
+		symbols = append(symbols, ManaSymbol{Text: strings.ToUpper(rest[1:end])})
+		rest = rest[end+1:]
+	}
+	return symbols, nil
+}
+
+// ManaCostSymbols returns the symbols of the card's mana cost.
+func (c *Card) ManaCostSymbols() ([]ManaSymbol, error) {
+	return ParseManaCost(c.ManaCost)
+}
+
+// RenderManaCost builds a representation of the mana cost by replacing each
+// symbol with the result of render, e.g. an HTML image tag per symbol.
+// A malformed mana cost is returned unchanged.
+func (c *Card) RenderManaCost(render func(ManaSymbol) string) string {
+	symbols, err := c.ManaCostSymbols()
+	if err != nil {
+		return c.ManaCost
+	}
+
+	var sb strings.Builder
+	for _, symbol := range symbols {
+		sb.WriteString(render(symbol))
+	}
+	return sb.String()
+}
@@ -0,0 +1,84 @@
+package mtg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseManaCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		cost    string
+		want    []string
+		wantErr bool
+	}{
+		{"generic and colored", "{2}{R}{R}", []string{"2", "R", "R"}, false},
+		{"hybrid", "{W/U}{W/U}", []string{"W/U", "W/U"}, false},
+		{"monocolored hybrid", "{2/B}", []string{"2/B"}, false},
+		{"phyrexian", "{1}{G/P}", []string{"1", "G/P"}, false},
+		{"variable", "{X}{X}{R}", []string{"X", "X", "R"}, false},
+		{"lowercase", "{x}{u}", []string{"X", "U"}, false},
+		{"spaces", " {3} ", []string{"3"}, false},
+		{"empty", "", nil, false},
+		{"missing brace", "{2}{R", nil, true},
+		{"empty symbol", "{}", nil, true},
+		{"text outside braces", "2R", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbols, err := ParseManaCost(tt.cost)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseManaCost(%q) error = %v, want error %v", tt.cost, err, tt.wantErr)
+			}
+			var got []string
+			for _, s := range symbols {
+				got = append(got, s.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseManaCost(%q) = %q, want %q", tt.cost, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManaSymbolKinds(t *testing.T) {
+	tests := []struct {
+		text       string
+		generic    int
+		isGeneric  bool
+		variable   bool
+		hybrid     bool
+		phyrexian  bool
+		wantColors []string
+	}{
+		{"3", 3, true, false, false, false, nil},
+		{"0", 0, true, false, false, false, nil},
+		{"X", 0, false, true, false, false, nil},
+		{"C", 0, false, false, false, false, nil},
+		{"G", 0, false, false, false, false, []string{"G"}},
+		{"W/U", 0, false, false, true, false, []string{"W", "U"}},
+		{"2/B", 0, false, false, true, false, []string{"B"}},
+		{"R/P", 0, false, false, false, true, []string{"R"}},
+		{"G/W/P", 0, false, false, true, true, []string{"W", "G"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			m := ManaSymbol{Text: tt.text}
+			if n, ok := m.Generic(); n != tt.generic || ok != tt.isGeneric {
+				t.Errorf("Generic() = %d, %v; want %d, %v", n, ok, tt.generic, tt.isGeneric)
+			}
+			if got := m.IsVariable(); got != tt.variable {
+				t.Errorf("IsVariable() = %v, want %v", got, tt.variable)
+			}
+			if got := m.IsHybrid(); got != tt.hybrid {
+				t.Errorf("IsHybrid() = %v, want %v", got, tt.hybrid)
+			}
+			if got := m.IsPhyrexian(); got != tt.phyrexian {
+				t.Errorf("IsPhyrexian() = %v, want %v", got, tt.phyrexian)
+			}
+			if got := m.Colors(); !reflect.DeepEqual(got, tt.wantColors) {
+				t.Errorf("Colors() = %q, want %q", got, tt.wantColors)
+			}
+		})
+	}
+}
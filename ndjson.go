@@ -0,0 +1,50 @@
+package mtg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxNDJSONLine is the longest line ImportCardsNDJSON accepts.
+const maxNDJSONLine = 16 << 20
+
+// ExportCardsNDJSON writes the cards as newline-delimited JSON, one card per
+// line. Cards are encoded one at a time, so memory use does not grow with the
+// number of cards.
+func ExportCardsNDJSON(w io.Writer, cards []*Card) error {
+	enc := json.NewEncoder(w)
+	for _, card := range cards {
+		if err := enc.Encode(card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportCardsNDJSON reads cards from newline-delimited JSON as written by
+// ExportCardsNDJSON. Blank lines are skipped; parse errors report the line.
+func ImportCardsNDJSON(r io.Reader) ([]*Card, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxNDJSONLine)
+
+	var cards []*Card
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		card := new(Card)
+		if err := json.Unmarshal(data, card); err != nil {
+			return nil, fmt.Errorf("Line %d: %w", line, err)
+		}
+		cards = append(cards, card)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
@@ -1,19 +1,30 @@
 package mtg
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const (
 	queryURL = "https://api.magicthegathering.io/v1/"
 )
 
+// Version is the version of this SDK, sent as part of the default UserAgent.
+const Version = "1.0.0"
+
+// UserAgent is sent with every request. Set it before making any requests to
+// identify your application to the API operators.
+var UserAgent = "mtg-sdk-go/" + Version
+
 var linkRE = regexp.MustCompile(`<(.*)>; rel="(.*)"`)
 
 type cardColumn string
@@ -105,22 +116,85 @@ var (
 	CardLegality = cardColumn("legality")
 )
 
+// QueryMode defines how multiple values for the same column are combined.
+// The API joins values with a comma for AND and with a pipe for OR.
+type QueryMode int
+
+const (
+	// And matches cards which have all of the given values.
+	And QueryMode = iota
+	// Or matches cards which have at least one of the given values.
+	Or
+)
+
+// join combines the values using the delimiter of the QueryMode.
+func (m QueryMode) join(values []string) string {
+	if m == Or {
+		return strings.Join(values, "|")
+	}
+	return strings.Join(values, ",")
+}
+
 // Query interface can be used to query multiple cards by their properties.
 type Query interface {
 	// Where filters the given column by the given value
 	Where(column cardColumn, query string) Query
+	// WhereName filters by the given card name
+	WhereName(name string) Query
+	// WhereArtist filters by the given artist name
+	WhereArtist(name string) Query
+	// WhereLayout filters by the given card layout
+	WhereLayout(layout Layout) Query
+	// WhereSet filters by the given set code
+	WhereSet(code SetCode) Query
+	// WhereSetName filters by the given set name
+	WhereSetName(name string) Query
+	// WhereCMCIn filters by cards with any of the given converted mana costs
+	WhereCMCIn(values ...float64) Query
+	// WhereColorIdentity filters by cards including all given identity colors
+	WhereColorIdentity(ids ...Color) Query
+	// WhereColorIdentityExact filters by cards with exactly the given identity
+	WhereColorIdentityExact(ids ...Color) Query
+	// WhereFormat filters by the given game format
+	WhereFormat(format string) Query
+	// WhereLegality filters by the given legality status
+	WhereLegality(status LegalityStatus) Query
+	// WhereType filters by the given types combined by mode
+	WhereType(mode QueryMode, types ...string) Query
+	// WhereSupertypes filters by the given supertypes combined by mode
+	WhereSupertypes(mode QueryMode, supertypes ...string) Query
+	// WhereSubtypes filters by the given subtypes combined by mode
+	WhereSubtypes(mode QueryMode, subtypes ...string) Query
 	// Sorts the query results by the given column
 	OrderBy(column cardColumn) Query
 	// Creates a copy of this query
 	Copy() Query
 	// Fetches all cards matching the current query
 	All() ([]*Card, error)
+	// Fetches at most maxItems cards matching the current query
+	AllUpTo(maxItems int) ([]*Card, error)
+	// Fetches all cards matching the current query, decoding them one by one
+	Stream(fn func(*Card) error) error
+	// Fetches all cards matching the current query, reporting the progress
+	AllWithProgress(progress func(fetched, total int)) ([]*Card, error)
+	// Fetches all cards matching the current query with parallel requests
+	AllConcurrent(parallelism int) ([]*Card, error)
 	// Fetches the given page of cards.
 	Page(pageNum int) (cards []*Card, totalCardCount int, err error)
 	// Fetches one page of cards with a given page size
 	PageS(pageNum int, pageSize int) (cards []*Card, totalCardCount int, err error)
+	// Fetches one page of cards together with the metadata of the response
+	PageMeta(pageNum int, pageSize int) (cards []*Card, meta ResultMeta, err error)
 	// Fetches some random cards
 	Random(count int) ([]*Card, error)
+	// Returns the request URL used to fetch the cards of this query
+	URL() string
+	// Serializes the query including its pagination position
+	Cursor() string
+	// Fetches only the current page and returns the cursor of the next one
+	SinglePage() (cards []*Card, nextCursor string, err error)
+	// Fetches all pages, passing each with the cursor of the next one to fn
+	EachPage(fn func(cards []*Card, nextCursor string) error) error
 }
 
 // NewQuery creates a new Query to fetch cards.
@@ -128,11 +202,50 @@ func NewQuery() Query {
 	return make(query)
 }
 
+// NewQueryFromCursor restores a Query from a string returned by Query.Cursor.
+// It also accepts a full request URL such as the next page of a Link header.
+// Fetching starts at the page stored in the cursor, so All resumes a scan
+// from there.
+func NewQueryFromCursor(cursor string) (Query, error) {
+	if i := strings.IndexByte(cursor, '?'); i >= 0 {
+		cursor = cursor[i+1:]
+	}
+	vals, err := url.ParseQuery(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cursor %q: %w", cursor, err)
+	}
+
+	q := make(query)
+	for k := range vals {
+		q[k] = vals.Get(k)
+	}
+	return q, nil
+}
+
 type query map[string]string
 
+// newRequest creates a GET request for rawURL which carries the UserAgent.
+func newRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	return req, nil
+}
+
+// httpGet works like http.Get but sends the UserAgent.
+func httpGet(rawURL string) (*http.Response, error) {
+	req, err := newRequest(context.Background(), rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
+}
+
 func fetchCards(url string) ([]*Card, http.Header, error) {
 	// resp is http.Response
-	resp, err := http.Get(url)
+	resp, err := httpGet(url)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -178,36 +291,224 @@ func decodeCards(reader io.Reader) ([]*Card, error) {
 }
 
 func (q query) All() ([]*Card, error) {
-	var allCards []*Card
+	return q.fetchAll(-1, nil)
+}
 
-	queryVals := make(url.Values)
-	for k, v := range q {
-		queryVals.Set(k, v)
+// AllUpTo fetches the cards matching the query, following the pagination
+// until maxItems cards have been collected. At most maxItems cards are returned.
+func (q query) AllUpTo(maxItems int) ([]*Card, error) {
+	if maxItems <= 0 {
+		return nil, nil
 	}
-	nextURL := queryURL + "cards?" + queryVals.Encode()
+	return q.fetchAll(maxItems, nil)
+}
+
+// AllWithProgress fetches all cards like All and calls progress after every
+// page with the number of cards fetched so far and the total count from the
+// Total-Count header, which is 0 if the API did not send it.
+func (q query) AllWithProgress(progress func(fetched, total int)) ([]*Card, error) {
+	return q.fetchAll(-1, progress)
+}
+
+// fetchAll follows the pagination until all cards or, if limit is not
+// negative, limit cards have been fetched. If progress is not nil it is called
+// after every page.
+func (q query) fetchAll(limit int, progress func(fetched, total int)) ([]*Card, error) {
+	var allCards []*Card
+	total := 0
+
+	nextURL := q.URL()
 	for nextURL != "" {
-		cards, header, err := fetchCards(nextURL)
+		cards, header, err := q.fetchFilteredCards(nextURL)
 		if err != nil {
 			return nil, err
 		}
 
-		// TODO: Investigate this line's impact on workflow.
-		nextURL = ""
-		if linkH, ok := header["Link"]; ok {
-			parts := strings.Split(linkH[0], ",")
-			for _, link := range parts {
-				match := linkRE.FindStringSubmatch(link)
-				if match != nil {
-					if match[2] == "next" {
-						nextURL = match[1]
-					}
-				}
+		nextURL = nextPageURL(header)
+		allCards = append(allCards, cards...)
+		if progress != nil {
+			if t, ok := parseTotalCount(header); ok {
+				total = t
+			}
+			progress(len(allCards), total)
+		}
+		if limit >= 0 && len(allCards) >= limit {
+			return allCards[:limit], nil
+		}
+	}
+	return allCards, nil
+}
+
+// maxCardPageSize is the largest number of cards the API returns per page,
+// whatever pageSize is requested.
+const maxCardPageSize = 100
+
+// AllConcurrent fetches the same cards as All, but requests the pages in
+// parallel with at most parallelism requests at a time. The first page is
+// fetched alone to learn the number of pages from the Total-Count header; if
+// the header is missing the remaining pages are followed serially instead.
+// Like All it starts at the page stored in the query, e.g. by a cursor, and a
+// pageSize set on the query is capped at maxCardPageSize.
+// The cards keep the order of All. If pages fail, their errors are joined
+// and no cards are returned; the same happens if the number of fetched cards
+// differs from Total-Count, e.g. because cards were added during the fetch.
+func (q query) AllConcurrent(parallelism int) ([]*Card, error) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	pageSize := maxCardPageSize
+	if n, err := strconv.Atoi(q["pageSize"]); err == nil && n > 0 && n < maxCardPageSize {
+		pageSize = n
+	}
+	startPage := 1
+	if n, err := strconv.Atoi(q["page"]); err == nil && n > 1 {
+		startPage = n
+	}
+
+	first, header, err := fetchCards(q.pageURL(startPage, pageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	total, ok := parseTotalCount(header)
+	if !ok {
+		allCards := first
+		for nextURL := nextPageURL(header); nextURL != ""; nextURL = nextPageURL(header) {
+			var cards []*Card
+			if cards, header, err = fetchCards(nextURL); err != nil {
+				return nil, err
 			}
+			allCards = append(allCards, cards...)
 		}
+		return q.filterCards(allCards), nil
+	}
+
+	lastPage := (total + pageSize - 1) / pageSize
+	if lastPage <= startPage {
+		return q.filterCards(first), nil
+	}
+
+	pageCount := lastPage - startPage + 1
+	pages := make([][]*Card, pageCount)
+	errs := make([]error, pageCount)
+	pages[0] = first
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i := 1; i < pageCount; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			pageNum := startPage + i
+			cards, _, err := fetchCards(q.pageURL(pageNum, pageSize))
+			if err != nil {
+				errs[i] = fmt.Errorf("Page %d: %w", pageNum, err)
+				return
+			}
+			pages[i] = cards
+		}(i)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
 
+	want := total - (startPage-1)*pageSize
+	allCards := make([]*Card, 0, want)
+	for _, cards := range pages {
 		allCards = append(allCards, cards...)
 	}
-	return allCards, nil
+	if len(allCards) != want {
+		return nil, fmt.Errorf("Fetched %d cards but Total-Count leaves %d from page %d", len(allCards), want, startPage)
+	}
+	return q.filterCards(allCards), nil
+}
+
+// pageURL returns the request URL for the given page of the query.
+func (q query) pageURL(pageNum int, pageSize int) string {
+	queryVals := q.values()
+	queryVals.Set("page", strconv.Itoa(pageNum))
+	queryVals.Set("pageSize", strconv.Itoa(pageSize))
+	return queryURL + "cards?" + queryVals.Encode()
+}
+
+// parseTotalCount reads the Total-Count header. The first valid count is
+// used if the header occurs several times; the bool is false if the header
+// is missing or holds no valid count.
+func parseTotalCount(header http.Header) (int, bool) {
+	for _, v := range header.Values("Total-Count") {
+		for _, part := range strings.Split(v, ",") {
+			if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && n >= 0 {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ResultMeta describes the response to a page request.
+type ResultMeta struct {
+	// Page is the requested page number.
+	Page int
+	// PageSize is the requested number of items per page.
+	PageSize int
+	// TotalCount is the number of items matching the query, taken from the
+	// Total-Count header. It falls back to the number of returned items if
+	// the header is missing.
+	TotalCount int
+	// RateLimit is the number of requests allowed per hour, or -1 if the
+	// Ratelimit-Limit header is missing.
+	RateLimit int
+	// RateLimitRemaining is the number of requests left in the current hour,
+	// or -1 if the Ratelimit-Remaining header is missing.
+	RateLimitRemaining int
+	// HasNextPage reports whether the Link header points to a next page.
+	HasNextPage bool
+}
+
+// newResultMeta builds the ResultMeta of a page response with count items.
+func newResultMeta(header http.Header, pageNum, pageSize, count int) ResultMeta {
+	meta := ResultMeta{
+		Page:               pageNum,
+		PageSize:           pageSize,
+		TotalCount:         count,
+		RateLimit:          -1,
+		RateLimitRemaining: -1,
+		HasNextPage:        nextPageURL(header) != "",
+	}
+	if total, ok := parseTotalCount(header); ok {
+		meta.TotalCount = total
+	}
+	if n, err := strconv.Atoi(header.Get("Ratelimit-Limit")); err == nil {
+		meta.RateLimit = n
+	}
+	if n, err := strconv.Atoi(header.Get("Ratelimit-Remaining")); err == nil {
+		meta.RateLimitRemaining = n
+	}
+	return meta
+}
+
+// nextPageURL returns the URL of the next page from the Link header or an
+// empty string if this is the last page.
+func nextPageURL(header http.Header) string {
+	nextURL := ""
+	if linkH, ok := header["Link"]; ok {
+		parts := strings.Split(linkH[0], ",")
+		for _, link := range parts {
+			match := linkRE.FindStringSubmatch(link)
+			if match != nil {
+				if match[2] == "next" {
+					nextURL = match[1]
+				}
+			}
+		}
+	}
+	return nextURL
 }
 
 func (q query) Page(pageNum int) ([]*Card, int, error) {
@@ -215,45 +516,112 @@ func (q query) Page(pageNum int) ([]*Card, int, error) {
 }
 
 func (q query) PageS(pageNum int, pageSize int) ([]*Card, int, error) {
-	var cards []*Card
-	totalCardCount := 0
+	cards, meta, err := q.PageMeta(pageNum, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cards, meta.TotalCount, nil
+}
 
+// PageMeta works like PageS but returns the page number, page size, total
+// count and rate limits of the response as ResultMeta.
+func (q query) PageMeta(pageNum int, pageSize int) ([]*Card, ResultMeta, error) {
+	cards, header, err := q.fetchFilteredCards(q.pageURL(pageNum, pageSize))
+	if err != nil {
+		return nil, ResultMeta{}, err
+	}
+	return cards, newResultMeta(header, pageNum, pageSize, len(cards)), nil
+}
+
+// values converts the query parameters sent to the API to url.Values.
+func (q query) values() url.Values {
 	queryVals := make(url.Values)
 	for k, v := range q {
+		if k == identityExactKey {
+			continue
+		}
 		queryVals.Set(k, v)
 	}
+	return queryVals
+}
 
-	queryVals.Set("page", strconv.Itoa(pageNum))
-	queryVals.Set("pageSize", strconv.Itoa(pageSize))
+// URL returns the encoded request URL for the cards matching the query,
+// without sending a request.
+func (q query) URL() string {
+	return queryURL + "cards?" + q.values().Encode()
+}
 
-	url := queryURL + "cards?" + queryVals.Encode()
-	cards, header, err := fetchCards(url)
+// Cursor serializes the filters of the query together with its pagination
+// position (page and pageSize, if set). Restore it with NewQueryFromCursor.
+// The position only changes by restoring a cursor; All, AllUpTo and Stream do
+// not report how far they got. To save progress while scanning, use the
+// cursors handed out by EachPage or SinglePage.
+func (q query) Cursor() string {
+	queryVals := q.values()
+	if codes, ok := q[identityExactKey]; ok {
+		queryVals.Set(identityExactKey, codes)
+	}
+	return queryVals.Encode()
+}
+
+// SinglePage fetches one page of cards without following the pagination. It
+// starts at the page stored in the query, the first one unless restored from
+// a cursor. The returned cursor leads to the next page when passed to
+// NewQueryFromCursor and is empty on the last page.
+func (q query) SinglePage() ([]*Card, string, error) {
+	cards, header, err := q.fetchFilteredCards(q.URL())
 	if err != nil {
-		return nil, 0, err
+		return nil, "", err
 	}
 
-	totalCardCount = len(cards)
-	if totals, ok := header["Total-Count"]; ok && len(totals) > 0 {
-		if totalCardCount, err = strconv.Atoi(totals[0]); err != nil {
-			return nil, 0, err
-		}
+	nextURL := nextPageURL(header)
+	if nextURL == "" {
+		return cards, "", nil
+	}
+	next, err := NewQueryFromCursor(nextURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if codes, ok := q[identityExactKey]; ok {
+		next.(query)[identityExactKey] = codes
 	}
+	return cards, next.Cursor(), nil
+}
 
-	return cards, totalCardCount, nil
+// EachPage fetches the cards matching the query page by page, starting at the
+// page stored in the query, and passes every page together with the cursor
+// of the next page to fn. The cursor is empty for the last page. A batch job
+// which stores the cursor after fn has processed a page can resume after a
+// crash with NewQueryFromCursor, without fetching the finished pages again.
+// If fn returns an error, fetching stops and that error is returned.
+func (q query) EachPage(fn func(cards []*Card, nextCursor string) error) error {
+	var next Query = q
+	for {
+		cards, cursor, err := next.SinglePage()
+		if err != nil {
+			return err
+		}
+		if err := fn(cards, cursor); err != nil {
+			return err
+		}
+		if cursor == "" {
+			return nil
+		}
+		if next, err = NewQueryFromCursor(cursor); err != nil {
+			return err
+		}
+	}
 }
 
 // Random cards by page size.
 func (q query) Random(count int) ([]*Card, error) {
-	queryVals := make(url.Values)
-	for k, v := range q {
-		queryVals.Set(k, v)
-	}
+	queryVals := q.values()
 
 	queryVals.Set("random", "true")
 	queryVals.Set("pageSize", strconv.Itoa(count))
 
 	url := queryURL + "cards?" + queryVals.Encode()
-	cards, _, err := fetchCards(url)
+	cards, _, err := q.fetchFilteredCards(url)
 	return cards, err
 }
 
@@ -272,6 +640,164 @@ func (q query) Where(column cardColumn, qry string) Query {
 	return q
 }
 
+// WhereName filters cards by name. The API matches names partially, use the
+// ByName filter on the results for an exact match.
+// Commas are part of many names and are sent unchanged: the API reads them as
+// AND, which still matches the full name. A split card name such as
+// "Fire // Ice" is queried as its halves joined with the OR pipe, because each
+// half has its own record.
+func (q query) WhereName(name string) Query {
+	if halves := strings.Split(name, "//"); len(halves) > 1 {
+		for i, half := range halves {
+			halves[i] = strings.TrimSpace(half)
+		}
+		return q.Where(CardName, Or.join(halves))
+	}
+	return q.Where(CardName, strings.TrimSpace(name))
+}
+
+// WhereArtist filters cards by artist. Like names, the API matches artists
+// partially, so "Poole" also finds cards by "Mark Poole".
+func (q query) WhereArtist(name string) Query {
+	return q.Where(CardArtist, name)
+}
+
+// WhereLayout filters cards by layout. The API recognizes the values of the
+// Layout constants: normal, split, flip, double-faced, token, plane, scheme,
+// phenomenon, leveler and vanguard.
+func (q query) WhereLayout(layout Layout) Query {
+	return q.Where(CardLayout, string(layout))
+}
+
+// WhereSet filters cards by the code of the set they belong to.
+func (q query) WhereSet(code SetCode) Query {
+	return q.Where(CardSet, string(code.Normalize()))
+}
+
+// WhereSetName filters cards by the name of the set they belong to.
+func (q query) WhereSetName(name string) Query {
+	return q.Where(CardSetName, name)
+}
+
+// WhereCMCIn filters cards whose converted mana cost is any of the given
+// values, joined with the API's OR delimiter. Values are formatted without
+// trailing zeros, e.g. "1|2|3" or "0.5".
+// Without values the filter on the CMC is removed.
+func (q query) WhereCMCIn(values ...float64) Query {
+	vals := make([]string, len(values))
+	for i, v := range values {
+		vals[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return q.whereJoined(CardCMC, Or, vals)
+}
+
+// whereJoined filters column by the values joined according to mode. Without
+// values the filter on column is removed instead of sending an empty one.
+func (q query) whereJoined(column cardColumn, mode QueryMode, values []string) Query {
+	if len(values) == 0 {
+		delete(q, string(column))
+		return q
+	}
+	return q.Where(column, mode.join(values))
+}
+
+// WhereColorIdentity filters cards whose color identity includes all of the
+// given colors; the colors are joined with the API's AND delimiter. Cards
+// with further colors match as well, e.g. WhereColorIdentity(ColorWhite)
+// also returns white-blue cards, while mono-blue and colorless cards are
+// excluded.
+//
+// The API cannot express subsets, so WhereColorIdentity does not find the
+// cards a commander may use. Fetch the cards without an identity filter and
+// apply ByColorIdentityWithin to them, or combine the results of one
+// WhereColorIdentityExact query per subset of the commander's colors.
+// Without colors the filter on the color identity is removed.
+func (q query) WhereColorIdentity(ids ...Color) Query {
+	return q.whereJoined(CardColorIdentity, And, colorCodes(ids))
+}
+
+// identityExactKey is the query key holding the colors passed to
+// WhereColorIdentityExact. It is not sent to the API.
+const identityExactKey = "colorIdentityExact"
+
+// WhereColorIdentityExact filters cards whose color identity consists of
+// exactly the given colors; without colors it matches colorless cards. The
+// API can only require colors, so the query is sent as WhereColorIdentity and
+// cards with further colors are dropped after fetching, as by
+// ByColorIdentityExact. Pages may therefore hold fewer cards than their page
+// size, and total counts refer to the cards including the given colors.
+func (q query) WhereColorIdentityExact(ids ...Color) Query {
+	q[identityExactKey] = And.join(colorCodes(ids))
+	return q.WhereColorIdentity(ids...)
+}
+
+// colorCodes converts colors to the codes used by the API.
+func colorCodes(ids []Color) []string {
+	codes := make([]string, len(ids))
+	for i, id := range ids {
+		codes[i] = string(id)
+	}
+	return codes
+}
+
+// filterCards drops the cards not matching the filters of q which the API
+// cannot apply, currently WhereColorIdentityExact.
+func (q query) filterCards(cards []*Card) []*Card {
+	codes, ok := q[identityExactKey]
+	if !ok {
+		return cards
+	}
+	var ids []Color
+	for _, code := range strings.Split(codes, ",") {
+		if code != "" {
+			ids = append(ids, Color(code))
+		}
+	}
+	return FilterCards(cards, ByColorIdentityExact(ids...))
+}
+
+// fetchFilteredCards works like fetchCards but applies filterCards.
+func (q query) fetchFilteredCards(url string) ([]*Card, http.Header, error) {
+	cards, header, err := fetchCards(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q.filterCards(cards), header, nil
+}
+
+// WhereFormat filters cards by game format, e.g. "Modern", using the
+// gameFormat parameter. Without WhereLegality the API assumes Legal.
+func (q query) WhereFormat(format string) Query {
+	return q.Where(CardGameFormat, format)
+}
+
+// WhereLegality filters cards by their legality in the format given with
+// WhereFormat, using the legality parameter, e.g.
+// WhereFormat("Modern").WhereLegality(LegalityBanned).
+func (q query) WhereLegality(status LegalityStatus) Query {
+	return q.Where(CardLegality, string(status))
+}
+
+// WhereType filters by card types joined according to the QueryMode, e.g.
+// WhereType(And, TypeArtifact, TypeCreature) for artifact creatures or
+// WhereType(Or, TypeInstant, TypeSorcery) for instants and sorceries.
+// Without types the filter on types is removed.
+func (q query) WhereType(mode QueryMode, types ...string) Query {
+	return q.whereJoined(CardTypes, mode, types)
+}
+
+// WhereSupertypes filters by supertypes joined according to the QueryMode.
+// Without supertypes the filter on supertypes is removed.
+func (q query) WhereSupertypes(mode QueryMode, supertypes ...string) Query {
+	return q.whereJoined(CardSupertypes, mode, supertypes)
+}
+
+// WhereSubtypes filters by subtypes joined according to the QueryMode.
+// Without subtypes the filter on subtypes is removed.
+func (q query) WhereSubtypes(mode QueryMode, subtypes ...string) Query {
+	return q.whereJoined(CardSubtypes, mode, subtypes)
+}
+
 func (q query) OrderBy(column cardColumn) Query {
 	q["orderBy"] = string(column)
 	return q
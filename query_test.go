@@ -0,0 +1,327 @@
+package mtg
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCardsSingleCard(t *testing.T) {
+	const body = `{"card":{"name":"Lightning Bolt","set":"LEA","id":"a1"}}`
+
+	cards, err := decodeCards(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("got %d cards, want 1", len(cards))
+	}
+	if cards[0].Name != "Lightning Bolt" || cards[0].Set != "LEA" || cards[0].ID != "a1" {
+		t.Errorf("got %+v", cards[0])
+	}
+}
+
+func TestDecodeCardsMultipleCards(t *testing.T) {
+	const body = `{"cards":[{"name":"Forest","id":"f1"},{"name":"Island","id":"i1"}]}`
+
+	cards, err := decodeCards(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("got %d cards, want 2", len(cards))
+	}
+	if cards[0].Name != "Forest" || cards[1].Name != "Island" {
+		t.Errorf("got %q and %q", cards[0].Name, cards[1].Name)
+	}
+}
+
+func TestDecodeCardsEmpty(t *testing.T) {
+	cards, err := decodeCards(strings.NewReader(`{"cards":[]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 0 {
+		t.Errorf("got %d cards, want none", len(cards))
+	}
+}
+
+func TestDecodeCardsInvalid(t *testing.T) {
+	if _, err := decodeCards(strings.NewReader(`{"cards":`)); err == nil {
+		t.Error("expected an error for truncated JSON")
+	}
+}
+
+func TestParseTotalCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   int
+		wantOK bool
+	}{
+		{"missing", nil, 0, false},
+		{"single", []string{"42"}, 42, true},
+		{"zero", []string{"0"}, 0, true},
+		{"spaces", []string{" 7 "}, 7, true},
+		{"comma joined", []string{"12, 12"}, 12, true},
+		{"repeated header", []string{"5", "6"}, 5, true},
+		{"invalid first", []string{"abc", "9"}, 9, true},
+		{"negative", []string{"-1"}, 0, false},
+		{"invalid", []string{"many"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header)
+			for _, v := range tt.values {
+				header.Add("Total-Count", v)
+			}
+			got, ok := parseTotalCount(header)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseTotalCount(%q) = %d, %v; want %d, %v", tt.values, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// stubAPI makes http.DefaultClient answer every request with the response
+// built by fn instead of contacting the API.
+func stubAPI(t *testing.T, fn func(r *http.Request) *http.Response) {
+	t.Helper()
+	oldTransport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return fn(r), nil
+	})
+	t.Cleanup(func() {
+		http.DefaultClient.Transport = oldTransport
+	})
+}
+
+// cardPages serves total numbered cards in pages of at most 100 cards, like
+// the API does whatever pageSize is requested, linking to the next page.
+func cardPages(total int) func(r *http.Request) *http.Response {
+	return func(r *http.Request) *http.Response {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		if size < 1 || size > 100 {
+			size = 100
+		}
+
+		var names []string
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			names = append(names, fmt.Sprintf(`{"name":"%d"}`, i))
+		}
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Total-Count", strconv.Itoa(total))
+		if page*size < total {
+			next := *r.URL
+			vals := next.Query()
+			vals.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = vals.Encode()
+			rec.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+		}
+		rec.WriteString(`{"cards":[` + strings.Join(names, ",") + `]}`)
+		return rec.Result()
+	}
+}
+
+func TestAllConcurrentCapsPageSize(t *testing.T) {
+	stubAPI(t, cardPages(250))
+
+	q, err := NewQueryFromCursor("pageSize=500")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards, err := q.AllConcurrent(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 250 {
+		t.Fatalf("got %d cards, want 250", len(cards))
+	}
+	for i, card := range cards {
+		if card.Name != strconv.Itoa(i) {
+			t.Fatalf("card %d is %q, want the order of the pages", i, card.Name)
+		}
+	}
+}
+
+func TestAllConcurrentDetectsMissingCards(t *testing.T) {
+	serve := cardPages(250)
+	stubAPI(t, func(r *http.Request) *http.Response {
+		resp := serve(r)
+		resp.Header.Set("Total-Count", "300")
+		return resp
+	})
+
+	if _, err := NewQuery().AllConcurrent(2); err == nil {
+		t.Error("expected an error when fewer cards than Total-Count arrive")
+	}
+}
+
+func TestAllConcurrentStartsAtRestoredPage(t *testing.T) {
+	stubAPI(t, cardPages(250))
+
+	q, err := NewQueryFromCursor("page=2&pageSize=100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards, err := q.AllConcurrent(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 150 || cards[0].Name != "100" {
+		t.Errorf("got %d cards starting at %q, want 150 starting at \"100\"", len(cards), cards[0].Name)
+	}
+}
+
+func TestEachPageResumesFromCursor(t *testing.T) {
+	stubAPI(t, cardPages(250))
+
+	var cursors []string
+	fetched := 0
+	err := NewQuery().EachPage(func(cards []*Card, nextCursor string) error {
+		fetched += len(cards)
+		cursors = append(cursors, nextCursor)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched != 250 || len(cursors) != 3 || cursors[2] != "" {
+		t.Fatalf("got %d cards and cursors %q, want 250 cards in 3 pages", fetched, cursors)
+	}
+
+	q, err := NewQueryFromCursor(cursors[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards, err := q.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 150 || cards[0].Name != "100" {
+		t.Errorf("resumed with %d cards starting at %q, want 150 starting at \"100\"", len(cards), cards[0].Name)
+	}
+}
+
+func TestEachPageStopsOnError(t *testing.T) {
+	stubAPI(t, cardPages(250))
+
+	stop := errors.New("stop")
+	pages := 0
+	err := NewQuery().EachPage(func([]*Card, string) error {
+		pages++
+		return stop
+	})
+	if !errors.Is(err, stop) || pages != 1 {
+		t.Errorf("got error %v after %d pages, want %v after 1", err, pages, stop)
+	}
+}
+
+func TestWhereColorIdentityExact(t *testing.T) {
+	var gotQuery string
+	serveCards(t, `{"cards":[
+		{"name":"Azorius Signet","colorIdentity":["W","U"]},
+		{"name":"Esper Charm","colorIdentity":["W","U","B"]},
+		{"name":"Sol Ring","colorIdentity":[]}
+	]}`, &gotQuery)
+
+	cards, err := NewQuery().WhereColorIdentityExact(ColorWhite, ColorBlue).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 1 || cards[0].Name != "Azorius Signet" {
+		t.Errorf("got %d cards, want only Azorius Signet", len(cards))
+	}
+	if strings.Contains(gotQuery, identityExactKey) || !strings.Contains(gotQuery, "colorIdentity=W%2CU") {
+		t.Errorf("request query %q, want only the colorIdentity filter", gotQuery)
+	}
+}
+
+func TestWhereColorIdentityExactCursor(t *testing.T) {
+	q, err := NewQueryFromCursor(NewQuery().WhereColorIdentityExact().Cursor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards := q.(query).filterCards([]*Card{
+		{Name: "Sol Ring"},
+		{Name: "Forest", ColorIdentity: []string{"G"}},
+	})
+	if len(cards) != 1 || cards[0].Name != "Sol Ring" {
+		t.Errorf("restored colorless filter kept %d cards, want only Sol Ring", len(cards))
+	}
+}
+
+func TestWhereCMCIn(t *testing.T) {
+	if got := NewQuery().WhereCMCIn(1, 2.5).(query)["cmc"]; got != "1|2.5" {
+		t.Errorf("got cmc %q, want \"1|2.5\"", got)
+	}
+	q := NewQuery().WhereCMCIn(3).WhereCMCIn().(query)
+	if _, ok := q["cmc"]; ok {
+		t.Errorf("WhereCMCIn() kept the filter %q, want it removed", q["cmc"])
+	}
+	if url := NewQuery().WhereCMCIn().URL(); strings.Contains(url, "cmc") {
+		t.Errorf("URL %q sends an empty cmc filter", url)
+	}
+}
+
+func TestWhereType(t *testing.T) {
+	tests := []struct {
+		q    Query
+		want string
+	}{
+		{NewQuery().WhereType(And, TypeArtifact, TypeCreature), "Artifact,Creature"},
+		{NewQuery().WhereType(Or, TypeInstant, TypeSorcery), "Instant|Sorcery"},
+		{NewQuery().WhereType(Or), ""},
+	}
+	for _, tt := range tests {
+		got, ok := tt.q.(query)["types"]
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("got types %q (set %v), want %q", got, ok, tt.want)
+		}
+	}
+}
+
+func TestWhereSupertypesAndSubtypes(t *testing.T) {
+	q := NewQuery().WhereSupertypes(And, "Legendary", "Snow").WhereSubtypes(Or, "Elf", "Goblin").(query)
+	if q["supertypes"] != "Legendary,Snow" || q["subtypes"] != "Elf|Goblin" {
+		t.Errorf("got supertypes %q and subtypes %q", q["supertypes"], q["subtypes"])
+	}
+
+	q = q.WhereSupertypes(And).WhereSubtypes(Or).(query)
+	if _, ok := q["supertypes"]; ok {
+		t.Error("WhereSupertypes without supertypes kept the filter")
+	}
+	if _, ok := q["subtypes"]; ok {
+		t.Error("WhereSubtypes without subtypes kept the filter")
+	}
+}
+
+func TestWhereColorIdentityWithoutColors(t *testing.T) {
+	q := NewQuery().WhereColorIdentity(ColorRed).WhereColorIdentity().(query)
+	if _, ok := q["colorIdentity"]; ok {
+		t.Errorf("WhereColorIdentity() kept the filter %q, want it removed", q["colorIdentity"])
+	}
+}
+
+func TestRequestsSendUserAgent(t *testing.T) {
+	var got string
+	stubAPI(t, func(r *http.Request) *http.Response {
+		got = r.Header.Get("User-Agent")
+		return cardPages(0)(r)
+	})
+
+	if _, err := NewQuery().All(); err != nil {
+		t.Fatal(err)
+	}
+	if want := "mtg-sdk-go/" + Version; got != want {
+		t.Errorf("got User-Agent %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package mtg
+
+import "sync"
+
+// reservedList caches the result of ReservedListCards.
+var reservedList struct {
+	sync.Mutex
+	cards []*Card
+}
+
+// ReservedListCards returns all cards on the Reserved List.
+// The API has no filter for reserved cards, so this fetches every card and
+// keeps the reserved ones, which takes many requests. The list rarely changes,
+// so the first successful result is cached for the lifetime of the process.
+// Every call returns its own copies of the cached cards, so callers may sort
+// or modify them.
+func ReservedListCards() ([]*Card, error) {
+	reservedList.Lock()
+	defer reservedList.Unlock()
+
+	if reservedList.cards != nil {
+		return cloneCards(reservedList.cards), nil
+	}
+
+	cards, err := NewQuery().All()
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := []*Card{}
+	for _, card := range cards {
+		if card.Reserved {
+			reserved = append(reserved, card)
+		}
+	}
+
+	reservedList.cards = reserved
+	return cloneCards(reserved), nil
+}
+
+// cloneCards returns deep copies of the given cards.
+func cloneCards(cards []*Card) []*Card {
+	clones := make([]*Card, len(cards))
+	for i, card := range cards {
+		clones[i] = card.Clone()
+	}
+	return clones
+}
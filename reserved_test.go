@@ -0,0 +1,29 @@
+package mtg
+
+import "testing"
+
+func TestReservedListCardsReturnsCopies(t *testing.T) {
+	reservedList.Lock()
+	reservedList.cards = []*Card{{Name: "Black Lotus", Reserved: true}, {Name: "Ancestral Recall", Reserved: true}}
+	reservedList.Unlock()
+	t.Cleanup(func() {
+		reservedList.Lock()
+		reservedList.cards = nil
+		reservedList.Unlock()
+	})
+
+	first, err := ReservedListCards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0], first[1] = first[1], first[0]
+	first[0].Name = "changed"
+
+	second, err := ReservedListCards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0].Name != "Black Lotus" || second[1].Name != "Ancestral Recall" {
+		t.Errorf("cache was modified through a returned slice: %q, %q", second[0].Name, second[1].Name)
+	}
+}
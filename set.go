@@ -2,11 +2,16 @@ package mtg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
@@ -14,8 +19,15 @@ var (
 	SetName = setColumn("name")
 	// SetBlock is the block the set is in
 	SetBlock = setColumn("block")
+	// SetReleaseDate is the release date of the set. It can only be used
+	// with OrderBy.
+	SetReleaseDate = setColumn("releaseDate")
 )
 
+// setOrderKey is the setQuery key holding the column set by OrderBy. It is
+// not sent to the API.
+const setOrderKey = "orderBy"
+
 // SetCode representing one specific Set of cards
 type (
 	SetCode   string
@@ -23,6 +35,38 @@ type (
 	setQuery  map[string]string
 )
 
+// SetType is the type of a set as given in Set.Type.
+type SetType string
+
+// Known set types. SetTypeUnknown is returned for any other value.
+const (
+	SetTypeUnknown      = SetType("")
+	SetTypeCore         = SetType("core")
+	SetTypeExpansion    = SetType("expansion")
+	SetTypeReprint      = SetType("reprint")
+	SetTypeBox          = SetType("box")
+	SetTypeUn           = SetType("un")
+	SetTypeFromTheVault = SetType("from the vault")
+	SetTypePremiumDeck  = SetType("premium deck")
+	SetTypeDuelDeck     = SetType("duel deck")
+	SetTypeStarter      = SetType("starter")
+	SetTypeCommander    = SetType("commander")
+	SetTypePlanechase   = SetType("planechase")
+	SetTypeArchenemy    = SetType("archenemy")
+	SetTypePromo        = SetType("promo")
+	SetTypeVanguard     = SetType("vanguard")
+	SetTypeMasters      = SetType("masters")
+)
+
+// knownSetTypes contains all SetTypes except SetTypeUnknown.
+var knownSetTypes = map[SetType]bool{
+	SetTypeCore: true, SetTypeExpansion: true, SetTypeReprint: true,
+	SetTypeBox: true, SetTypeUn: true, SetTypeFromTheVault: true,
+	SetTypePremiumDeck: true, SetTypeDuelDeck: true, SetTypeStarter: true,
+	SetTypeCommander: true, SetTypePlanechase: true, SetTypeArchenemy: true,
+	SetTypePromo: true, SetTypeVanguard: true, SetTypeMasters: true,
+}
+
 // BoosterContent represent one or more types of cards within a booster
 type BoosterContent []string
 
@@ -64,21 +108,65 @@ type Set struct {
 type SetQuery interface {
 	// Where filters the given column by the given value.
 	Where(col setColumn, qry string) SetQuery
+	// OrderBy sorts the Sets by the given column.
+	OrderBy(col setColumn) SetQuery
 	// Copy creates a copy of the SetQuery.
 	Copy() SetQuery
 	// All returns alls Sets which match the query.
 	All() ([]*Set, error)
+	// AllUpTo returns at most maxItems Sets which match the query.
+	AllUpTo(maxItems int) ([]*Set, error)
 	// Page returns the Sets for given page and total count of matching sets.
 	// The default PageSize is 500. See also PageS.
 	Page(pageNum int) (sets []*Set, totalSetCount int, err error)
 	// PageS returns the Sets for given page and page size.
 	// It also returns the total count of sets matching the query.
 	PageS(pageNum int, pageSize int) (sets []*Set, totalSetCount int, err error)
+	// PageMeta returns the Sets for given page and page size together with
+	// the metadata of the response.
+	PageMeta(pageNum int, pageSize int) (sets []*Set, meta ResultMeta, err error)
+	// Count returns the total count of sets matching the query.
+	Count() (int, error)
+	// URL returns the request URL used to fetch the sets of this query.
+	URL() string
+}
+
+// Normalize returns the set code trimmed and in upper case, e.g. "M21" for
+// " m21". The lower case 'p' of old promo codes is upper cased as well, so
+// "pMEI" becomes "PMEI".
+func (s SetCode) Normalize() SetCode {
+	return SetCode(strings.ToUpper(strings.TrimSpace(string(s))))
+}
+
+// setCodeRE matches well-formed normalized set codes such as "M21", "PMEI"
+// or "DD3_DVD".
+var setCodeRE = regexp.MustCompile(`^[A-Z0-9]{3,6}(_[A-Z0-9]+)?$`)
+
+// validSetCodes caches the set codes which were validated successfully.
+var validSetCodes sync.Map
+
+// Validate checks that the set code is well-formed and belongs to a known set.
+// The format is checked locally before the set is fetched from the API.
+// Codes which were found are cached, so repeated validations are free.
+func (s SetCode) Validate() error {
+	s = s.Normalize()
+	if !setCodeRE.MatchString(string(s)) {
+		return fmt.Errorf("Invalid set code %q", string(s))
+	}
+	if _, ok := validSetCodes.Load(s); ok {
+		return nil
+	}
+
+	if _, err := s.Fetch(); err != nil {
+		return err
+	}
+	validSetCodes.Store(s, true)
+	return nil
 }
 
 // GenerateBooster returns a slice of booster cards for the given set.
 func (s SetCode) GenerateBooster() ([]*Card, error) {
-	cards, _, err := fetchCards(fmt.Sprintf("%ssets/%s/booster", queryURL, s))
+	cards, _, err := fetchCards(fmt.Sprintf("%ssets/%s/booster", queryURL, s.Normalize()))
 	return cards, err
 }
 
@@ -111,11 +199,172 @@ func (b *BoosterContent) String() string {
 	return s
 }
 
+// SetType returns the typed Type of the set.
+// Unrecognized values result in SetTypeUnknown.
+func (s *Set) SetType() SetType {
+	t := SetType(strings.ToLower(strings.TrimSpace(s.Type)))
+	if !knownSetTypes[t] {
+		return SetTypeUnknown
+	}
+	return t
+}
+
+// setsNotOnGatherer lists the codes of sets which Gatherer does not have,
+// besides the four letter promo codes starting with a lowercase 'p'.
+var setsNotOnGatherer = map[string]bool{
+	"ATH": true, "ITP": true, "DKM": true, "RQS": true, "DPA": true,
+}
+
+// gathererCode returns the code Gatherer uses for the set, falling back to
+// the SetCode as GathererCode is only present if it differs.
+func (s *Set) gathererCode() string {
+	if s.GathererCode != "" {
+		return s.GathererCode
+	}
+	return string(s.SetCode)
+}
+
+// GathererURL returns the Gatherer search page listing the cards of the set.
+// The bool is false for sets which are not on Gatherer.
+func (s *Set) GathererURL() (string, bool) {
+	code := s.gathererCode()
+	if setsNotOnGatherer[code] || (len(code) == 4 && code[0] == 'p') {
+		return "", false
+	}
+	return "https://gatherer.wizards.com/Pages/Search/Default.aspx?set=" +
+		url.QueryEscape(fmt.Sprintf("[%q]", s.Name)), true
+}
+
+// MagicCardsInfoURL returns the magiccards.info page of the set.
+// The bool is false if magiccards.info does not have the set, which is the
+// case when MagicCardsInfoCode is empty.
+func (s *Set) MagicCardsInfoURL() (string, bool) {
+	if s.MagicCardsInfoCode == "" {
+		return "", false
+	}
+	return fmt.Sprintf("https://magiccards.info/%s/en.html", strings.ToLower(s.MagicCardsInfoCode)), true
+}
+
 // String returns the string representation for the Set.
 func (s *Set) String() string {
 	return fmt.Sprintf("%s (%s)", s.Name, s.SetCode)
 }
 
+// CompareSetReleaseDates orders two sets by their release date.
+// It returns -1 if a was released before b, 1 if it was released after b and
+// 0 otherwise. Sets with a missing or malformed release date are ordered
+// after all others; ties are broken by the set name.
+func CompareSetReleaseDates(a, b *Set) int {
+	da, errA := parseDate(a.ReleaseDate)
+	db, errB := parseDate(b.ReleaseDate)
+	switch {
+	case errA != nil && errB == nil:
+		return 1
+	case errA == nil && errB != nil:
+		return -1
+	case errA == nil && errB == nil && !da.Equal(db):
+		if da.Before(db) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// SortSetsByReleaseDate sorts the sets chronologically, oldest first.
+// See CompareSetReleaseDates for the handling of ties and malformed dates.
+func SortSetsByReleaseDate(sets []*Set) {
+	sort.SliceStable(sets, func(i, j int) bool {
+		return CompareSetReleaseDates(sets[i], sets[j]) < 0
+	})
+}
+
+// LatestSet returns the most recently released set which was not only
+// released online. Sets with a release date in the future are ignored.
+func LatestSet() (*Set, error) {
+	return latestSet(false)
+}
+
+// LatestSetIncludingOnline works like LatestSet but also considers sets which
+// were only released online.
+func LatestSetIncludingOnline() (*Set, error) {
+	return latestSet(true)
+}
+
+func latestSet(includeOnlineOnly bool) (*Set, error) {
+	sets, err := NewSetQuery().All()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var latest *Set
+	var latestDate time.Time
+	for _, set := range sets {
+		if set.OnlineOnly && !includeOnlineOnly {
+			continue
+		}
+		date, err := parseDate(set.ReleaseDate)
+		if err != nil || date.After(now) {
+			continue
+		}
+		if latest == nil || date.After(latestDate) ||
+			(date.Equal(latestDate) && set.Name < latest.Name) {
+			latest, latestDate = set, date
+		}
+	}
+
+	if latest == nil {
+		return nil, errors.New("No released set found")
+	}
+	return latest, nil
+}
+
+// PreviousSet returns the set released right before the set with the given
+// code, or nil if it is the first set. Sets are ordered as by
+// SortSetsByReleaseDate; sets without a valid release date are left out and
+// have no neighbors.
+func PreviousSet(code SetCode) (*Set, error) {
+	return adjacentSet(code, -1)
+}
+
+// NextSet returns the set released right after the set with the given code,
+// or nil if it is the latest set. See PreviousSet for the order.
+func NextSet(code SetCode) (*Set, error) {
+	return adjacentSet(code, 1)
+}
+
+// adjacentSet fetches all sets and returns the one offset positions away from
+// the set with the given code in release order.
+func adjacentSet(code SetCode, offset int) (*Set, error) {
+	all, err := NewSetQuery().All()
+	if err != nil {
+		return nil, err
+	}
+
+	code = code.Normalize()
+	var sets []*Set
+	for _, set := range all {
+		if _, err := parseDate(set.ReleaseDate); err == nil {
+			sets = append(sets, set)
+		} else if set.SetCode.Normalize() == code {
+			return nil, fmt.Errorf("Set %s has no valid release date", code)
+		}
+	}
+	SortSetsByReleaseDate(sets)
+
+	for i, set := range sets {
+		if set.SetCode.Normalize() != code {
+			continue
+		}
+		if j := i + offset; j >= 0 && j < len(sets) {
+			return sets[j], nil
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("Set %s not found", code)
+}
+
 // NewSetQuery returns a new SetQuery.
 func NewSetQuery() SetQuery {
 	return make(setQuery)
@@ -123,7 +372,7 @@ func NewSetQuery() SetQuery {
 
 // Fetch returns the Set of the given SetCode.
 func (s SetCode) Fetch() (*Set, error) {
-	sets, _, err := fetchSets(fmt.Sprintf("%ssets/%s", queryURL, s))
+	sets, _, err := fetchSets(fmt.Sprintf("%ssets/%s", queryURL, s.Normalize()))
 	if err != nil {
 		return nil, err
 	}
@@ -134,8 +383,163 @@ func (s SetCode) Fetch() (*Set, error) {
 	return sets[0], nil
 }
 
+// LoadSetWithCards fetches the Set of the given code together with all of its
+// cards. Both are requested concurrently; if either fails an error is returned.
+func LoadSetWithCards(code SetCode) (*Set, []*Card, error) {
+	var cards []*Card
+	var cardsErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cards, cardsErr = NewQuery().WhereSet(code).All()
+	}()
+
+	set, err := code.Fetch()
+	<-done
+	if err != nil {
+		return nil, nil, err
+	}
+	if cardsErr != nil {
+		return nil, nil, cardsErr
+	}
+	return set, cards, nil
+}
+
+// syncSetsPerQuery limits how many set codes SyncCardsSince joins into one
+// query to keep the request URL short.
+const syncSetsPerQuery = 20
+
+// SyncCardsSince returns a best-effort list of the cards added since ts.
+// The API cannot report changes, so this returns all cards of the sets
+// released after ts. A set with a partial release date, such as "2020", is
+// included if any part of that period is after ts. Cards added to older sets
+// and errata are not detected.
+func SyncCardsSince(ts time.Time) ([]*Card, error) {
+	sets, err := NewSetQuery().All()
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	for _, set := range sets {
+		if _, end, err := parseDatePeriod(set.ReleaseDate); err == nil && end.After(ts) {
+			codes = append(codes, string(set.SetCode))
+		}
+	}
+
+	var cards []*Card
+	for len(codes) > 0 {
+		n := syncSetsPerQuery
+		if n > len(codes) {
+			n = len(codes)
+		}
+		batch, err := NewQuery().Where(CardSet, Or.join(codes[:n])).All()
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, batch...)
+		codes = codes[n:]
+	}
+	return cards, nil
+}
+
+// FetchSetByName returns the Set with the given name.
+// The API matches names partially, so the results are narrowed down to the
+// sets whose name equals the given one, ignoring case.
+func FetchSetByName(name string) (*Set, error) {
+	sets, err := NewSetQuery().Where(SetName, name).All()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Set
+	for _, set := range sets {
+		if strings.EqualFold(set.Name, name) {
+			matches = append(matches, set)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("Set %q not found", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("Set name %q is ambiguous, %d sets match", name, len(matches))
+	}
+}
+
+// FetchSetByAnyCode returns the Set identified by code, which may be its
+// primary code, Gatherer code, old code or magiccards.info code. All sets are
+// fetched and the codes are compared ignoring case in that order of
+// precedence, so a primary code always wins over other kinds of codes.
+func FetchSetByAnyCode(code string) (*Set, error) {
+	sets, err := NewSetQuery().All()
+	if err != nil {
+		return nil, err
+	}
+
+	codeFields := []func(*Set) string{
+		func(s *Set) string { return string(s.SetCode) },
+		func(s *Set) string { return s.GathererCode },
+		func(s *Set) string { return s.OldCode },
+		func(s *Set) string { return s.MagicCardsInfoCode },
+	}
+	for _, field := range codeFields {
+		var matches []*Set
+		for _, set := range sets {
+			if c := field(set); c != "" && strings.EqualFold(c, code) {
+				matches = append(matches, set)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			return nil, fmt.Errorf("Set code %q is ambiguous, %d sets match", code, len(matches))
+		}
+	}
+	return nil, fmt.Errorf("Set %q not found", code)
+}
+
+// fetchSetsParallelism limits the concurrent requests of FetchSets.
+const fetchSetsParallelism = 4
+
+// FetchSets returns the Sets of the given codes in the same order, fetching
+// them concurrently. If some codes fail, the errors of all of them are joined
+// and their entries in the result are nil; the other sets are still returned.
+func FetchSets(codes ...SetCode) ([]*Set, error) {
+	sets := make([]*Set, len(codes))
+	errs := make([]error, len(codes))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, fetchSetsParallelism)
+	for i, code := range codes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, code SetCode) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			set, err := code.Fetch()
+			if err != nil {
+				errs[i] = fmt.Errorf("Set %s: %w", code, err)
+				return
+			}
+			sets[i] = set
+		}(i, code)
+	}
+	wg.Wait()
+
+	return sets, errors.Join(errs...)
+}
+
 func fetchSets(url string) ([]*Set, http.Header, error) {
-	resp, err := http.Get(url)
+	resp, err := httpGet(url)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -163,38 +567,69 @@ func fetchSets(url string) ([]*Set, http.Header, error) {
 
 // All returns alls Sets which match the query
 func (q setQuery) All() ([]*Set, error) {
-	var allSets []*Set
+	return q.fetchAll(-1)
+}
 
-	queryVals := make(url.Values)
-	for k, v := range q {
-		queryVals.Set(k, v)
+// AllUpTo returns at most maxItems Sets which match the query, following
+// the pagination only as far as needed.
+func (q setQuery) AllUpTo(maxItems int) ([]*Set, error) {
+	if maxItems <= 0 {
+		return nil, nil
 	}
-	nextURL := queryURL + "sets?" + queryVals.Encode()
+	return q.fetchAll(maxItems)
+}
+
+// fetchAll follows the pagination until all sets or, if limit is not
+// negative, limit sets have been fetched.
+func (q setQuery) fetchAll(limit int) ([]*Set, error) {
+	var allSets []*Set
+	ordered := q[setOrderKey] != ""
+
+	nextURL := q.URL()
 	for nextURL != "" {
 		sets, header, err := fetchSets(nextURL)
 		if err != nil {
 			return nil, err
 		}
 
-		nextURL = ""
-
-		if linkH, ok := header["Link"]; ok {
-			parts := strings.Split(linkH[0], ",")
-			for _, link := range parts {
-				match := linkRE.FindStringSubmatch(link)
-				if match != nil {
-					if match[2] == "next" {
-						nextURL = match[1]
-					}
-				}
-			}
+		nextURL = nextPageURL(header)
+		allSets = append(allSets, sets...)
+		if !ordered && limit >= 0 && len(allSets) >= limit {
+			return allSets[:limit], nil
 		}
+	}
 
-		allSets = append(allSets, sets...)
+	sortSets(allSets, setColumn(q[setOrderKey]))
+	if limit >= 0 && len(allSets) > limit {
+		allSets = allSets[:limit]
 	}
 	return allSets, nil
 }
 
+// OrderBy sorts the Sets by the given column: SetName, SetBlock or
+// SetReleaseDate. Sets of the same block are ordered by release date.
+// The API does not sort sets, so they are sorted after fetching: All and
+// AllUpTo fetch every matching set before sorting, while Page, PageS and
+// PageMeta only sort the sets within the requested page.
+func (q setQuery) OrderBy(col setColumn) SetQuery {
+	q[setOrderKey] = string(col)
+	return q
+}
+
+// sortSets sorts sets by the given column. Unknown columns, including the
+// empty one, keep the order of the API.
+func sortSets(sets []*Set, col setColumn) {
+	switch col {
+	case SetName:
+		sort.SliceStable(sets, func(i, j int) bool { return sets[i].Name < sets[j].Name })
+	case SetBlock:
+		SortSetsByReleaseDate(sets)
+		sort.SliceStable(sets, func(i, j int) bool { return sets[i].Block < sets[j].Block })
+	case SetReleaseDate:
+		SortSetsByReleaseDate(sets)
+	}
+}
+
 // Page returns the Sets of a given page and total count of sets matching the query.
 // The default PageSize is 500. See also PageS
 func (q setQuery) Page(pageNum int) (sets []*Set, totalSetCount int, err error) {
@@ -204,13 +639,17 @@ func (q setQuery) Page(pageNum int) (sets []*Set, totalSetCount int, err error)
 // PageS returns Sets of the given page and page size.
 // It also returns the total count of sets which match the query.
 func (q setQuery) PageS(pageNum int, pageSize int) ([]*Set, int, error) {
-	var sets []*Set
-	totalSetCount := 0
-
-	queryVals := make(url.Values)
-	for k, v := range q {
-		queryVals.Set(k, v)
+	sets, meta, err := q.PageMeta(pageNum, pageSize)
+	if err != nil {
+		return nil, 0, err
 	}
+	return sets, meta.TotalCount, nil
+}
+
+// PageMeta works like PageS but returns the page number, page size, total
+// count and rate limits of the response as ResultMeta.
+func (q setQuery) PageMeta(pageNum int, pageSize int) ([]*Set, ResultMeta, error) {
+	queryVals := q.values()
 
 	queryVals.Set("page", strconv.Itoa(pageNum))
 	queryVals.Set("pageSize", strconv.Itoa(pageSize))
@@ -218,17 +657,54 @@ func (q setQuery) PageS(pageNum int, pageSize int) ([]*Set, int, error) {
 	url := queryURL + "sets?" + queryVals.Encode()
 	sets, header, err := fetchSets(url)
 	if err != nil {
-		return nil, 0, err
+		return nil, ResultMeta{}, err
+	}
+	sortSets(sets, setColumn(q[setOrderKey]))
+	return sets, newResultMeta(header, pageNum, pageSize, len(sets)), nil
+}
+
+// Count returns the total count of sets matching the query.
+// It requests a single set and reads the Total-Count header without decoding
+// the response body.
+func (q setQuery) Count() (int, error) {
+	queryVals := q.values()
+
+	queryVals.Set("page", "1")
+	queryVals.Set("pageSize", "1")
+
+	resp, err := httpGet(queryURL + "sets?" + queryVals.Encode())
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkError(resp); err != nil {
+		return 0, err
+	}
+
+	total, ok := parseTotalCount(resp.Header)
+	if !ok {
+		return 0, errors.New("Total-Count header missing or invalid")
 	}
+	return total, nil
+}
 
-	totalSetCount = len(sets)
-	if totals, ok := header["Total-Count"]; ok && len(totals) > 0 {
-		if totalSetCount, err = strconv.Atoi(totals[0]); err != nil {
-			return nil, 0, err
+// values converts the query parameters to url.Values.
+func (q setQuery) values() url.Values {
+	queryVals := make(url.Values)
+	for k, v := range q {
+		if k == setOrderKey {
+			continue
 		}
+		queryVals.Set(k, v)
 	}
+	return queryVals
+}
 
-	return sets, totalSetCount, nil
+// URL returns the encoded request URL for the sets matching the query,
+// without sending a request.
+func (q setQuery) URL() string {
+	return queryURL + "sets?" + q.values().Encode()
 }
 
 // Copy creates a copy of the SetQuery.
@@ -0,0 +1,172 @@
+package mtg
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestSetCodeNormalize(t *testing.T) {
+	tests := map[SetCode]SetCode{
+		"m21":     "M21",
+		" M21 ":   "M21",
+		"pMEI":    "PMEI",
+		"PLST":    "PLST",
+		"dd3_dvd": "DD3_DVD",
+	}
+	for code, want := range tests {
+		if got := code.Normalize(); got != want {
+			t.Errorf("%q.Normalize() = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestIndexBySetNumberIgnoresCase(t *testing.T) {
+	idx := NewIndex()
+	idx.AddCards([]*Card{{Name: "Forest", Set: "m21", Number: "274"}})
+	if _, ok := idx.BySetNumber("M21", "274"); !ok {
+		t.Error("lookup by upper case set code failed")
+	}
+}
+
+func TestWhereSetNormalizes(t *testing.T) {
+	q := NewQuery().WhereSet(" m21 ").(query)
+	if q["set"] != "M21" {
+		t.Errorf("got set %q, want M21", q["set"])
+	}
+}
+
+func TestSetCodeFormat(t *testing.T) {
+	tests := map[SetCode]bool{
+		"M21":      true,
+		"pMEI":     true,
+		"10E":      true,
+		"DD3_DVD":  true,
+		"FRF_UGIN": true,
+		"ab":       false,
+		"M21_":     false,
+		"A B":      false,
+		"TOOLONG7": false,
+	}
+	for code, want := range tests {
+		if got := setCodeRE.MatchString(string(code.Normalize())); got != want {
+			t.Errorf("format of %q valid = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestCardValidateUnderscoreSetCode(t *testing.T) {
+	c := &Card{Name: "Ugin, the Spirit Dragon", Set: "FRF_UGIN"}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+// testSets are served by serveSets: one undated set and three dated ones.
+var testSets = map[string]string{
+	"UND": `{"code":"UND","name":"Undated"}`,
+	"AAA": `{"code":"AAA","name":"First","releaseDate":"2001-01-01"}`,
+	"BBB": `{"code":"BBB","name":"Second","releaseDate":"2002-01-01"}`,
+	"CCC": `{"code":"CCC","name":"Third","releaseDate":"2003-01"}`,
+}
+
+// serveSets answers set requests with testSets and card requests with a
+// printing of the requested card in the requested set. It returns the number
+// of set requests received so far.
+func serveSets(t *testing.T) *int {
+	setRequests := new(int)
+	stubAPI(t, func(r *http.Request) *http.Response {
+		rec := httptest.NewRecorder()
+		if strings.Contains(r.URL.Path, "/sets") {
+			*setRequests++
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/sets"):
+			all := make([]string, 0, len(testSets))
+			for _, code := range []string{"UND", "CCC", "AAA", "BBB"} {
+				all = append(all, testSets[code])
+			}
+			rec.WriteString(`{"sets":[` + strings.Join(all, ",") + `]}`)
+		case strings.Contains(r.URL.Path, "/sets/"):
+			rec.WriteString(`{"set":` + testSets[path.Base(r.URL.Path)] + `}`)
+		default:
+			q := r.URL.Query()
+			fmt.Fprintf(rec, `{"cards":[{"name":%q,"set":%q}]}`, q.Get("name"), q.Get("set"))
+		}
+		return rec.Result()
+	})
+	return setRequests
+}
+
+func TestAdjacentSets(t *testing.T) {
+	serveSets(t)
+
+	tests := []struct {
+		code       SetCode
+		prev, next string
+	}{
+		{"AAA", "", "BBB"},
+		{"bbb", "AAA", "CCC"},
+		{"CCC", "BBB", ""},
+	}
+	for _, tt := range tests {
+		prev, err := PreviousSet(tt.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := NextSet(tt.code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code := setCodeOf(prev); code != tt.prev {
+			t.Errorf("PreviousSet(%q) = %q, want %q", tt.code, code, tt.prev)
+		}
+		if code := setCodeOf(next); code != tt.next {
+			t.Errorf("NextSet(%q) = %q, want %q", tt.code, code, tt.next)
+		}
+	}
+
+	if _, err := NextSet("UND"); err == nil {
+		t.Error("expected an error for a set without release date")
+	}
+}
+
+// setCodeOf returns the code of set, or an empty string for nil.
+func setCodeOf(set *Set) string {
+	if set == nil {
+		return ""
+	}
+	return string(set.SetCode)
+}
+
+func TestFetchLatestPrintingIgnoresUndatedSets(t *testing.T) {
+	setRequests := serveSets(t)
+
+	c := &Card{Name: "Forest", Set: "AAA", Printings: []SetCode{"AAA", "UND", "CCC", "BBB"}}
+	latest, err := c.FetchLatestPrinting()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Set != "CCC" {
+		t.Errorf("latest printing is from %q, want CCC", latest.Set)
+	}
+	if *setRequests != 1 {
+		t.Errorf("sent %d set requests, want 1", *setRequests)
+	}
+}
+
+func TestFetchLatestPrintingWithoutDates(t *testing.T) {
+	serveSets(t)
+
+	c := &Card{Name: "Forest", Set: "UND", Printings: []SetCode{"UND", "UND"}}
+	latest, err := c.FetchLatestPrinting()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != c {
+		t.Errorf("got a printing from %q, want the card itself", latest.Set)
+	}
+}
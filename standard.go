@@ -1,7 +1,9 @@
 package mtg
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -10,10 +12,30 @@ import (
 
 // StandardCards returns slice of cards in Standard.
 func StandardCards() ([]*Card, error) {
+	return LegalCards("Standard")
+}
+
+// LegalCards returns all cards which are legal in the given format.
+func LegalCards(format string) ([]*Card, error) {
+	return cardsByLegality(format, LegalityLegal)
+}
+
+// RestrictedCards returns all cards which are restricted in the given format.
+func RestrictedCards(format string) ([]*Card, error) {
+	return cardsByLegality(format, LegalityRestricted)
+}
+
+// BannedCards returns all cards which are banned in the given format.
+func BannedCards(format string) ([]*Card, error) {
+	return cardsByLegality(format, LegalityBanned)
+}
+
+// cardsByLegality fetches all pages of cards with the given legality in format.
+func cardsByLegality(format string, legality LegalityStatus) ([]*Card, error) {
 	// NewQuery is mtg.Query.
-	query := NewQuery().Where(CardGameFormat, "Standard")
+	query := NewQuery().WhereFormat(format)
 	// cards is mtg.[]*Card
-	cards, err := query.Where(CardLegality, "Legal").All()
+	cards, err := query.WhereLegality(legality).All()
 	if err != nil {
 		return nil, err
 	}
@@ -21,23 +43,60 @@ func StandardCards() ([]*Card, error) {
 	return cards, nil
 }
 
+// randomCandidates is the number of random cards requested per attempt of
+// RandomCardInFormat.
+const randomCandidates = 10
+
+// RandomCardInFormat returns a random card which is legal in the given format.
+// The legality filter is sent along with the random parameter, and each
+// returned card is checked again, so the result is legal even if the API
+// picked random cards before applying the filter.
+func RandomCardInFormat(format string) (*Card, error) {
+	cards, err := NewQuery().WhereFormat(format).WhereLegality(LegalityLegal).Random(randomCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, card := range cards {
+		if card.IsLegalIn(format) {
+			return card, nil
+		}
+	}
+	return nil, fmt.Errorf("No random card legal in %s found", format)
+}
+
 // StandardSets returns map of set names in Standard.
 func StandardSets() (map[string]SetCode, error) {
+	return StandardSetsContext(context.Background())
+}
+
+// StandardSetsContext works like StandardSets but uses ctx for the request.
+// Errors name whatsinstandard.com so they are not mistaken for API failures.
+func StandardSetsContext(ctx context.Context) (map[string]SetCode, error) {
 	URL := "https://whatsinstandard.com/api/v6/standard.json"
-	resp, err := http.Get(URL)
+	req, err := newRequest(ctx, URL)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Requesting whatsinstandard.com: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Requesting whatsinstandard.com: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Requesting whatsinstandard.com: %s", resp.Status)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Reading whatsinstandard.com response: %w", err)
 	}
 
 	var stdResp standardResp
 	if err := json.Unmarshal(body, &stdResp); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Decoding whatsinstandard.com response: %w", err)
 	}
 
 	standardSets := make(map[string]SetCode)
@@ -47,7 +106,7 @@ func StandardSets() (map[string]SetCode, error) {
 			setItem.ExitDate.Exact,
 		)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("Parsing whatsinstandard.com dates of %s: %w", setItem.Code, err)
 		}
 
 		if isStandard {
@@ -0,0 +1,64 @@
+package mtg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// serveCards makes http.DefaultClient answer every request with body instead
+// of contacting the API, and records the query of the last request in
+// *gotQuery.
+func serveCards(t *testing.T, body string, gotQuery *string) {
+	t.Helper()
+	stubAPI(t, func(r *http.Request) *http.Response {
+		*gotQuery = r.URL.RawQuery
+		rec := httptest.NewRecorder()
+		rec.WriteString(body)
+		return rec.Result()
+	})
+}
+
+func TestRandomCardInFormatSkipsIllegalCards(t *testing.T) {
+	var gotQuery string
+	serveCards(t, `{"cards":[
+		{"name":"Black Lotus","legalities":[{"format":"Commander","legality":"Banned"}]},
+		{"name":"Forest","legalities":[{"format":"Modern","legality":"Legal"}]},
+		{"name":"Sol Ring","legalities":[{"format":"Commander","legality":"Legal"}]}
+	]}`, &gotQuery)
+
+	card, err := RandomCardInFormat("Commander")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Name != "Sol Ring" {
+		t.Errorf("got %q, want the only legal card Sol Ring", card.Name)
+	}
+
+	q, err := NewQueryFromCursor(gotQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := q.(query)
+	if vals["random"] != "true" || vals["gameFormat"] != "Commander" || vals["legality"] != "Legal" {
+		t.Errorf("request query %q lacks the random, format or legality filter", gotQuery)
+	}
+}
+
+func TestRandomCardInFormatNoLegalCard(t *testing.T) {
+	var gotQuery string
+	serveCards(t, `{"cards":[
+		{"name":"Black Lotus","legalities":[{"format":"Commander","legality":"Banned"}]}
+	]}`, &gotQuery)
+
+	if card, err := RandomCardInFormat("Commander"); err == nil {
+		t.Errorf("got %q, want an error as no card is legal", card.Name)
+	}
+}
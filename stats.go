@@ -0,0 +1,53 @@
+package mtg
+
+// Stats summarizes a deck.
+type Stats struct {
+	// Cards is the number of cards in the deck.
+	Cards int
+	// ManaCurve counts the non-land cards per converted mana cost.
+	ManaCurve map[int]int
+	// ColorPips counts the colored mana symbols in the mana costs.
+	// Hybrid symbols count towards each of their colors.
+	ColorPips map[Color]int
+	// TypeCounts counts the cards per type; cards with several types, such
+	// as artifact creatures, count towards each of them.
+	TypeCounts map[string]int
+	// AverageCMC is the average converted mana cost of the non-land cards.
+	AverageCMC float64
+}
+
+// DeckStats computes the mana curve, color distribution, type breakdown and
+// average converted mana cost of the given cards. Each entry counts as one
+// card, so pass duplicates for multiple copies.
+func DeckStats(cards []*Card) Stats {
+	stats := Stats{
+		Cards:      len(cards),
+		ManaCurve:  make(map[int]int),
+		ColorPips:  make(map[Color]int),
+		TypeCounts: CountByType(cards),
+	}
+
+	var spells int
+	var totalCMC float64
+	for _, card := range cards {
+		if symbols, err := card.ManaCostSymbols(); err == nil {
+			for _, symbol := range symbols {
+				for _, c := range symbol.Colors() {
+					stats.ColorPips[Color(c)]++
+				}
+			}
+		}
+
+		if containsFold(card.Types, TypeLand) {
+			continue
+		}
+		spells++
+		totalCMC += card.CMC
+		stats.ManaCurve[card.CMCInt()]++
+	}
+
+	if spells > 0 {
+		stats.AverageCMC = totalCMC / float64(spells)
+	}
+	return stats
+}
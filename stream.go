@@ -0,0 +1,116 @@
+package mtg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Stream fetches all cards matching the query like All, but decodes them one
+// at a time and passes each to fn instead of collecting them, keeping memory
+// use flat for large results. If fn returns an error, streaming stops and
+// that error is returned.
+func (q query) Stream(fn func(*Card) error) error {
+	keep := func(card *Card) error {
+		if len(q.filterCards([]*Card{card})) == 0 {
+			return nil
+		}
+		return fn(card)
+	}
+
+	nextURL := q.URL()
+	for nextURL != "" {
+		header, err := streamCards(nextURL, keep)
+		if err != nil {
+			return err
+		}
+		nextURL = nextPageURL(header)
+	}
+	return nil
+}
+
+// streamCards fetches one page of cards and decodes it incrementally.
+func streamCards(url string, fn func(*Card) error) (http.Header, error) {
+	resp, err := httpGet(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkError(resp); err != nil {
+		return nil, err
+	}
+
+	if err := decodeCardStream(resp.Body, fn); err != nil {
+		return nil, err
+	}
+	return resp.Header, nil
+}
+
+// decodeCardStream walks a cards response token by token. Elements of the
+// "cards" array and a single "card" object are decoded one at a time and
+// passed to fn; null cards and all other fields are skipped.
+func decodeCardStream(r io.Reader, fn func(*Card) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case "cards":
+			if err := expectDelim(dec, '['); err != nil {
+				return err
+			}
+			for dec.More() {
+				if err := decodeStreamCard(dec, fn); err != nil {
+					return err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return err
+			}
+		case "card":
+			if err := decodeStreamCard(dec, fn); err != nil {
+				return err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("Unexpected token %v, expected %v", tok, delim)
+	}
+	return nil
+}
+
+// decodeStreamCard decodes the next card and passes it to fn unless it is
+// null.
+func decodeStreamCard(dec *json.Decoder, fn func(*Card) error) error {
+	var card *Card
+	if err := dec.Decode(&card); err != nil {
+		return err
+	}
+	if card == nil {
+		return nil
+	}
+	return fn(card)
+}
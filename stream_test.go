@@ -0,0 +1,61 @@
+package mtg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCardStream(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"cards array", `{"cards":[{"name":"Forest"},{"name":"Island"}]}`, []string{"Forest", "Island"}},
+		{"single card", `{"card":{"name":"Lightning Bolt"}}`, []string{"Lightning Bolt"}},
+		{"other fields skipped", `{"meta":{"page":1,"list":[1,2]},"cards":[{"name":"Swamp"}],"next":null}`, []string{"Swamp"}},
+		{"empty", `{"cards":[]}`, nil},
+		{"null card", `{"card":null}`, nil},
+		{"null in cards array", `{"cards":[null,{"name":"Plains"}]}`, []string{"Plains"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := decodeCardStream(strings.NewReader(tt.body), func(c *Card) error {
+				got = append(got, c.Name)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCardStreamStopsOnError(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := decodeCardStream(strings.NewReader(`{"cards":[{"name":"A"},{"name":"B"}]}`), func(*Card) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("got error %v, want %v", err, stop)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestDecodeCardStreamInvalid(t *testing.T) {
+	for _, body := range []string{`[]`, `{"cards":{}}`, `{"cards":[{"name":"A"}`} {
+		err := decodeCardStream(strings.NewReader(body), func(*Card) error { return nil })
+		if err == nil {
+			t.Errorf("decodeCardStream(%q) succeeded, want an error", body)
+		}
+	}
+}
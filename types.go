@@ -1,13 +1,23 @@
 package mtg
 
-import (
-	"encoding/json"
-	"net/http"
+import "encoding/json"
+
+// Common card types for WhereType and ByType.
+const (
+	TypeArtifact     = "Artifact"
+	TypeBattle       = "Battle"
+	TypeCreature     = "Creature"
+	TypeEnchantment  = "Enchantment"
+	TypeInstant      = "Instant"
+	TypeLand         = "Land"
+	TypePlaneswalker = "Planeswalker"
+	TypeSorcery      = "Sorcery"
+	TypeTribal       = "Tribal"
 )
 
 // GetTypes fetches a list of all card types.
 func GetTypes() ([]string, error) {
-	resp, err := http.Get(queryURL + "types")
+	resp, err := httpGet(queryURL + "types")
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +40,7 @@ func GetTypes() ([]string, error) {
 
 // GetSuperTypes fetches a list of all card supertypes.
 func GetSuperTypes() ([]string, error) {
-	resp, err := http.Get(queryURL + "supertypes")
+	resp, err := httpGet(queryURL + "supertypes")
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +63,7 @@ func GetSuperTypes() ([]string, error) {
 
 // GetSubTypes fetches a list of all card subtypes.
 func GetSubTypes() ([]string, error) {
-	resp, err := http.Get(queryURL + "subtypes")
+	resp, err := httpGet(queryURL + "subtypes")
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +86,7 @@ func GetSubTypes() ([]string, error) {
 
 // GetFormats fetches a list of all known game formats.
 func GetFormats() ([]string, error) {
-	resp, err := http.Get(queryURL + "formats")
+	resp, err := httpGet(queryURL + "formats")
 	if err != nil {
 		return nil, err
 	}
@@ -96,3 +106,77 @@ func GetFormats() ([]string, error) {
 
 	return res.Formats, nil
 }
+
+// creatureTypes lists the creature types of the Comprehensive Rules (205.3m).
+// The subtypes endpoint does not tell creature types apart from other
+// subtypes, so the vocabulary is kept here.
+var creatureTypes = []string{
+	"Advisor", "Aetherborn", "Alien", "Ally", "Angel", "Antelope", "Ape",
+	"Archer", "Archon", "Armadillo", "Army", "Artificer", "Assassin",
+	"Assembly-Worker", "Astartes", "Atog", "Aurochs", "Avatar", "Azra",
+	"Badger", "Barbarian", "Bard", "Basilisk", "Bat", "Bear", "Beast",
+	"Beeble", "Beholder", "Berserker", "Bird", "Blinkmoth", "Boar", "Bringer",
+	"Brushwagg", "C'tan", "Camarid", "Camel", "Caribou", "Carrier", "Cat",
+	"Centaur", "Child", "Chimera", "Citizen", "Cleric", "Clown", "Cockatrice",
+	"Construct", "Coward", "Coyote", "Crab", "Crocodile", "Custodes",
+	"Cyberman", "Cyclops", "Dalek", "Dauthi", "Demigod", "Demon", "Deserter",
+	"Detective", "Devil", "Dinosaur", "Djinn", "Doctor", "Dog", "Dragon",
+	"Drake", "Dreadnought", "Drone", "Druid", "Dryad", "Dwarf", "Efreet",
+	"Egg", "Elder", "Eldrazi", "Elemental", "Elephant", "Elf", "Elk", "Eye",
+	"Faerie", "Fish", "Flagbearer", "Fox", "Fractal", "Frog", "Fungus",
+	"Gargoyle", "Germ", "Giant", "Gith", "Glimmer", "Gnoll", "Gnome", "Goat",
+	"Goblin", "God", "Golem", "Gorgon", "Graveborn", "Gremlin", "Griffin",
+	"Hag", "Halfling", "Harpy", "Hellion", "Hippo", "Hippogriff", "Homarid",
+	"Homunculus", "Horror", "Horse", "Human", "Hydra", "Hyena", "Illusion",
+	"Imp", "Incarnation", "Inkling", "Insect", "Jackal", "Jellyfish",
+	"Juggernaut", "Kavu", "Kirin", "Kithkin", "Knight", "Kobold", "Kor",
+	"Kraken", "Lamia", "Lammasu", "Leech", "Leviathan", "Lhurgoyf", "Licid",
+	"Lizard", "Manticore", "Masticore", "Mercenary", "Merfolk", "Metathran",
+	"Minion", "Minotaur", "Mite", "Mole", "Monger", "Mongoose", "Monk",
+	"Monkey", "Moonfolk", "Mount", "Mouse", "Mutant", "Myr", "Mystic",
+	"Nautilus", "Necron", "Nephilim", "Nightmare", "Nightstalker", "Ninja",
+	"Noble", "Noggle", "Nomad", "Nymph", "Octopus", "Ogre", "Ooze", "Orb",
+	"Orc", "Orgg", "Otter", "Ouphe", "Ox", "Oyster", "Pegasus", "Peasant",
+	"Pentavite", "Performer", "Pest", "Phelddagrif", "Phoenix", "Phyrexian",
+	"Pilot", "Pincher", "Pirate", "Plant", "Porcupine", "Possum", "Praetor",
+	"Primarch", "Prism", "Processor", "Rabbit", "Raccoon", "Ranger", "Rat",
+	"Rebel", "Reflection", "Rhino", "Rigger", "Robot", "Rogue", "Sable",
+	"Salamander", "Samurai", "Saproling", "Satyr", "Scarecrow", "Scientist",
+	"Scion", "Scorpion", "Scout", "Serf", "Serpent", "Servo", "Shade",
+	"Shaman", "Shapeshifter", "Shark", "Sheep", "Siren", "Skeleton", "Slith",
+	"Sliver", "Sloth", "Slug", "Snail", "Snake", "Soldier", "Soltari",
+	"Spawn", "Specter", "Spellshaper", "Sphinx", "Spider", "Spike", "Spirit",
+	"Splinter", "Sponge", "Squid", "Squirrel", "Starfish", "Surrakar",
+	"Survivor", "Tentacle", "Tetravite", "Thalakos", "Thopter", "Thrull",
+	"Tiefling", "Time Lord", "Toy", "Treefolk", "Triskelavite", "Troll",
+	"Turtle", "Tyranid", "Unicorn", "Vampire", "Varmint", "Vedalken",
+	"Volver", "Wall", "Walrus", "Warlock", "Warrior", "Weird", "Werewolf",
+	"Whale", "Wizard", "Wolf", "Wolverine", "Wombat", "Worm", "Wraith",
+	"Wurm", "Yeti", "Zombie", "Zubera",
+}
+
+// isCreatureType contains the creature types for fast lookups.
+var isCreatureType = func() map[string]bool {
+	m := make(map[string]bool, len(creatureTypes))
+	for _, t := range creatureTypes {
+		m[t] = true
+	}
+	return m
+}()
+
+// CreatureTypes returns the list of all creature types.
+func CreatureTypes() []string {
+	return append([]string(nil), creatureTypes...)
+}
+
+// CreatureSubtypes returns the subtypes of the card which are creature types,
+// leaving out subtypes such as Equipment or Aura.
+func (c *Card) CreatureSubtypes() []string {
+	var result []string
+	for _, subtype := range c.Subtypes {
+		if isCreatureType[subtype] {
+			result = append(result, subtype)
+		}
+	}
+	return result
+}